@@ -22,9 +22,10 @@ import (
 	"evermos-api/internal/delivery/http/handler"
 	"evermos-api/internal/repository"
 	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
 	"fmt"
 	"log"
-	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -47,9 +48,33 @@ func main() {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
-	// Create uploads directory if not exists
-	if err := os.MkdirAll(cfg.Upload.Path, os.ModePerm); err != nil {
-		log.Fatalf("Failed to create uploads directory: %v", err)
+	// Assign unique slugs to rows created before slugs were enforced, then
+	// let the database enforce them
+	if err := usecase.BackfillSlugs(db); err != nil {
+		log.Printf("Warning: failed to backfill slugs: %v", err)
+	} else {
+		config.CreateSlugIndexes(db)
+	}
+
+	// Initialize upload storage (creates local uploads directory if needed)
+	store, err := config.InitStorage(&cfg.Upload, cfg.Upload.Driver)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Configure image processing for uploads
+	utils.ImageProcessing = utils.ImageOptions{
+		MaxDimension:   cfg.Upload.ImageMaxDimension,
+		ThumbnailSizes: cfg.Upload.ThumbnailSizes,
+		JPEGQuality:    cfg.Upload.JPEGQuality,
+	}
+
+	// Configure media URL generation and signing
+	utils.Media = utils.MediaOptions{
+		BaseURL:         cfg.Media.PublicBaseURL,
+		SigningSecret:   cfg.Media.SigningSecret,
+		SignedURLTTL:    cfg.Media.SignedURLTTL,
+		PrivatePrefixes: cfg.Media.PrivatePrefixes,
 	}
 
 	// Initialize repositories
@@ -62,26 +87,78 @@ func main() {
 	logProdukRepo := repository.NewLogProdukRepository(db)
 	trxRepo := repository.NewTrxRepository(db)
 	detailTrxRepo := repository.NewDetailTrxRepository(db)
+	reviewRepo := repository.NewReviewRepository(db)
+	slugRedirectRepo := repository.NewSlugRedirectRepository(db)
+	bannedKeywordRepo := repository.NewBannedKeywordRepository(db)
+	notificationRepo := repository.NewNotificationRepository(db)
+	categoryAttributeRepo := repository.NewCategoryAttributeRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	produkPromoRepo := repository.NewProdukPromoRepository(db)
+	priceHistoryRepo := repository.NewPriceHistoryRepository(db)
+	stockSubscriptionRepo := repository.NewStockSubscriptionRepository(db)
+	recommendationRepo := repository.NewRecommendationRepository(db)
+	popularityRepo := repository.NewPopularityRepository(db)
+	wishlistRepo := repository.NewWishlistRepository(db)
+	downloadGrantRepo := repository.NewDownloadGrantRepository(db)
+	tokoFollowerRepo := repository.NewTokoFollowerRepository(db)
 
 	// Initialize usecases
 	authUsecase := usecase.NewAuthUsecase(userRepo, tokoRepo, db)
-	tokoUsecase := usecase.NewTokoUsecase(tokoRepo)
+	wilayahUsecase := usecase.NewWilayahUsecase()
+	tokoUsecase := usecase.NewTokoUsecase(tokoRepo, tokoFollowerRepo, slugRedirectRepo, wilayahUsecase, db)
 	alamatUsecase := usecase.NewAlamatUsecase(alamatRepo)
-	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo)
-	produkUsecase := usecase.NewProdukUsecase(produkRepo, tokoRepo, fotoProdukRepo, logProdukRepo, db)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, categoryAttributeRepo)
+	moderationUsecase := usecase.NewModerationUsecase(produkRepo, tokoRepo, categoryRepo, bannedKeywordRepo, cfg.Moderation.MinTrustLevel, cfg.Moderation.KeywordCacheTTL, db)
+	produkUsecase := usecase.NewProdukUsecase(produkRepo, tokoRepo, categoryRepo, categoryAttributeRepo, produkPromoRepo, wishlistRepo, fotoProdukRepo, logProdukRepo, slugRedirectRepo, moderationUsecase, db)
 	trxUsecase := usecase.NewTrxUsecase(trxRepo, detailTrxRepo, produkRepo, logProdukRepo, alamatRepo, db)
-	wilayahUsecase := usecase.NewWilayahUsecase()
 	userUsecase := usecase.NewUserUsecase(userRepo, wilayahUsecase)
+	reviewUsecase := usecase.NewReviewUsecase(reviewRepo, detailTrxRepo, tokoRepo, db)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, db)
+	promoUsecase := usecase.NewPromoUsecase(produkPromoRepo, produkRepo, tokoRepo)
+	priceHistoryUsecase := usecase.NewPriceHistoryUsecase(priceHistoryRepo, logProdukRepo, produkRepo, tokoRepo)
+	stockSubscriptionUsecase := usecase.NewStockSubscriptionUsecase(stockSubscriptionRepo, produkRepo)
+	preorderUsecase := usecase.NewPreorderUsecase(detailTrxRepo, tokoRepo)
+	recommendationUsecase := usecase.NewRecommendationUsecase(
+		recommendationRepo,
+		produkRepo,
+		produkPromoRepo,
+		cfg.Recommendation.LookbackDays,
+		cfg.Recommendation.CacheTTL,
+	)
+	popularityUsecase := usecase.NewPopularityUsecase(
+		popularityRepo,
+		produkPromoRepo,
+		cfg.Popularity.WindowDays,
+		cfg.Popularity.ViewDedupeWindow,
+	)
+	wishlistUsecase := usecase.NewWishlistUsecase(wishlistRepo, produkRepo, produkPromoRepo)
+	downloadUsecase := usecase.NewDownloadUsecase(downloadGrantRepo, trxRepo)
+	tokoFollowerUsecase := usecase.NewTokoFollowerUsecase(tokoFollowerRepo, tokoRepo, produkPromoRepo, wishlistRepo, db)
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(authUsecase, cfg.JWT.Secret, cfg.JWT.ExpireHours)
 	userHandler := handler.NewUserHandler(userUsecase)
-	tokoHandler := handler.NewTokoHandler(tokoUsecase, cfg.Upload.Path)
+	tokoHandler := handler.NewTokoHandler(tokoUsecase, store)
 	alamatHandler := handler.NewAlamatHandler(alamatUsecase)
 	categoryHandler := handler.NewCategoryHandler(categoryUsecase)
-	produkHandler := handler.NewProdukHandler(produkUsecase, cfg.Upload.Path)
+	produkHandler := handler.NewProdukHandler(produkUsecase, store, cfg.Trash.RetentionDays)
 	trxHandler := handler.NewTrxHandler(trxUsecase)
 	wilayahHandler := handler.NewWilayahHandler(wilayahUsecase)
+	reviewHandler := handler.NewReviewHandler(reviewUsecase, store)
+	mediaHandler := handler.NewMediaHandler(store)
+	moderationHandler := handler.NewModerationHandler(moderationUsecase)
+	notificationHandler := handler.NewNotificationHandler(notificationUsecase)
+	tagHandler := handler.NewTagHandler(tagUsecase)
+	promoHandler := handler.NewPromoHandler(promoUsecase)
+	priceHistoryHandler := handler.NewPriceHistoryHandler(priceHistoryUsecase)
+	stockSubscriptionHandler := handler.NewStockSubscriptionHandler(stockSubscriptionUsecase)
+	preorderHandler := handler.NewPreorderHandler(preorderUsecase)
+	recommendationHandler := handler.NewRecommendationHandler(recommendationUsecase)
+	popularityHandler := handler.NewPopularityHandler(popularityUsecase)
+	wishlistHandler := handler.NewWishlistHandler(wishlistUsecase)
+	downloadHandler := handler.NewDownloadHandler(downloadUsecase)
+	tokoFollowerHandler := handler.NewTokoFollowerHandler(tokoFollowerUsecase)
 
 	// Initialize router
 	router := http.NewRouter(
@@ -93,9 +170,80 @@ func main() {
 		produkHandler,
 		trxHandler,
 		wilayahHandler,
+		reviewHandler,
+		mediaHandler,
+		moderationHandler,
+		notificationHandler,
+		tagHandler,
+		promoHandler,
+		priceHistoryHandler,
+		stockSubscriptionHandler,
+		preorderHandler,
+		recommendationHandler,
+		popularityHandler,
+		wishlistHandler,
+		downloadHandler,
+		tokoFollowerHandler,
 		cfg.JWT.Secret,
 	)
 
+	// Periodically purge products that stayed in the trash past retention
+	if cfg.Trash.PurgeInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Trash.PurgeInterval)
+			defer ticker.Stop()
+			for range ticker.C {
+				cutoff := time.Now().AddDate(0, 0, -cfg.Trash.RetentionDays)
+				purged, err := produkUsecase.PurgeDeletedProduk(cutoff, store)
+				if err != nil {
+					log.Printf("Warning: failed to purge deleted products: %v", err)
+				} else if purged > 0 {
+					log.Printf("Purged %d deleted products", purged)
+				}
+			}
+		}()
+	}
+
+	// Recompute "customers also bought" pairs at startup and periodically
+	if cfg.Recommendation.RefreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Recommendation.RefreshInterval)
+			defer ticker.Stop()
+			for ; true; <-ticker.C {
+				pairs, err := recommendationUsecase.RefreshCoPurchases()
+				if err != nil {
+					log.Printf("Warning: failed to refresh co-purchases: %v", err)
+				} else {
+					log.Printf("Refreshed %d co-purchase pairs", pairs)
+				}
+			}
+		}()
+	}
+
+	// Write buffered product views in the background
+	go func() {
+		ticker := time.NewTicker(cfg.Popularity.ViewFlushInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := popularityUsecase.FlushViews(); err != nil {
+				log.Printf("Warning: failed to flush product views: %v", err)
+			}
+		}
+	}()
+
+	// Recompute popularity scores at startup and periodically
+	if cfg.Popularity.RefreshInterval > 0 {
+		go func() {
+			ticker := time.NewTicker(cfg.Popularity.RefreshInterval)
+			defer ticker.Stop()
+			for ; true; <-ticker.C {
+				if _, err := popularityUsecase.RefreshScores(); err != nil {
+					log.Printf("Warning: failed to refresh popularity scores: %v", err)
+				}
+			}
+		}()
+	}
+
 	// Setup Gin
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
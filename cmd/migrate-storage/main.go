@@ -0,0 +1,107 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : main.go
+// Description  : Command untuk memindahkan file upload antar storage backend
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini menyalin semua file dari storage sumber ke storage tujuan
+// - Key file tetap sama sehingga URL di database tidak perlu diubah
+// - Contoh: go run ./cmd/migrate-storage -from local -to s3 -delete
+//
+// ============================================================================
+
+package main
+
+import (
+	"errors"
+	"evermos-api/internal/config"
+	"evermos-api/internal/storage"
+	"flag"
+	"io"
+	"log"
+)
+
+func main() {
+	from := flag.String("from", "local", "source storage driver (local or s3)")
+	to := flag.String("to", "s3", "destination storage driver (local or s3)")
+	prefix := flag.String("prefix", "", "only migrate keys with this prefix")
+	overwrite := flag.Bool("overwrite", false, "overwrite files that already exist in destination")
+	deleteSource := flag.Bool("delete", false, "delete source files after successful copy")
+	flag.Parse()
+
+	if *from == *to {
+		log.Fatalf("Source and destination storage must differ")
+	}
+
+	// Load configuration
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	src, err := config.InitStorage(&cfg.Upload, *from)
+	if err != nil {
+		log.Fatalf("Failed to initialize source storage: %v", err)
+	}
+
+	dst, err := config.InitStorage(&cfg.Upload, *to)
+	if err != nil {
+		log.Fatalf("Failed to initialize destination storage: %v", err)
+	}
+
+	keys, err := src.List(*prefix)
+	if err != nil {
+		log.Fatalf("Failed to list source files: %v", err)
+	}
+
+	var copied, skipped, failed int
+	for _, key := range keys {
+		if !*overwrite {
+			if _, err := dst.Stat(key); err == nil {
+				skipped++
+				continue
+			} else if !errors.Is(err, storage.ErrNotFound) {
+				log.Printf("Failed to check %s: %v", key, err)
+				failed++
+				continue
+			}
+		}
+
+		if err := copyFile(src, dst, key); err != nil {
+			log.Printf("Failed to copy %s: %v", key, err)
+			failed++
+			continue
+		}
+		copied++
+
+		if *deleteSource {
+			if err := src.Delete(key); err != nil {
+				log.Printf("Warning: failed to delete source %s: %v", key, err)
+			}
+		}
+	}
+
+	log.Printf("Storage migration finished: %d copied, %d skipped, %d failed", copied, skipped, failed)
+	if failed > 0 {
+		log.Fatalf("Storage migration completed with errors")
+	}
+}
+
+func copyFile(src, dst storage.Storage, key string) error {
+	reader, err := src.Get(key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+
+	return dst.Put(key, data, storage.ContentType(key))
+}
@@ -10,7 +10,9 @@
 // Notes:
 // - File ini membaca konfigurasi dari file .env
 // - Mengatur konfigurasi database, JWT, server, dan upload
-// - Menyediakan default values untuk setiap konfigurasi
+// - Upload dapat disimpan di local disk atau S3-compatible storage
+// - Menyediakan default values untuk setiap konfigurasi, kecuali
+//   MEDIA_SIGNING_SECRET yang wajib diisi dan berbeda dari JWT_SECRET
 //
 // ============================================================================
 
@@ -21,16 +23,23 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration
 type Config struct {
-	Database DatabaseConfig
-	JWT      JWTConfig
-	Server   ServerConfig
-	Upload   UploadConfig
+	Database       DatabaseConfig
+	JWT            JWTConfig
+	Server         ServerConfig
+	Upload         UploadConfig
+	Media          MediaConfig
+	Moderation     ModerationConfig
+	Trash          TrashConfig
+	Recommendation RecommendationConfig
+	Popularity     PopularityConfig
 }
 
 // DatabaseConfig holds database configuration
@@ -55,8 +64,66 @@ type ServerConfig struct {
 
 // UploadConfig holds upload configuration
 type UploadConfig struct {
-	Path          string
-	MaxUploadSize int64
+	Driver            string
+	Path              string
+	MaxUploadSize     int64
+	ImageMaxDimension int
+	ThumbnailSizes    []int
+	JPEGQuality       int
+	S3                S3Config
+}
+
+// S3Config holds S3-compatible storage configuration
+type S3Config struct {
+	Endpoint     string
+	Region       string
+	Bucket       string
+	AccessKey    string
+	SecretKey    string
+	UsePathStyle bool
+}
+
+// MediaConfig holds media serving configuration
+type MediaConfig struct {
+	PublicBaseURL   string
+	SigningSecret   string
+	SignedURLTTL    time.Duration
+	PrivatePrefixes []string
+}
+
+// ModerationConfig holds product moderation configuration
+type ModerationConfig struct {
+	// Toko below this trust level need admin approval for their products
+	MinTrustLevel int
+	// Banned keywords are reloaded from the database after KeywordCacheTTL so
+	// changes made on another instance are picked up
+	KeywordCacheTTL time.Duration
+}
+
+// TrashConfig holds soft-deleted product retention configuration
+type TrashConfig struct {
+	RetentionDays int
+	PurgeInterval time.Duration
+}
+
+// RecommendationConfig holds related product recommendation configuration
+type RecommendationConfig struct {
+	// Co-purchases are computed from transactions of the last LookbackDays
+	LookbackDays    int
+	RefreshInterval time.Duration
+	CacheTTL        time.Duration
+}
+
+// PopularityConfig holds product view tracking and popularity configuration
+type PopularityConfig struct {
+	// Popularity scores use views and sales of the last WindowDays
+	WindowDays      int
+	RefreshInterval time.Duration
+	// Buffered views are written to the database every ViewFlushInterval
+	ViewFlushInterval time.Duration
+	// Repeated views of a product by the same viewer within ViewDedupeWindow
+	// are counted once
+	ViewDedupeWindow time.Duration
 }
 
 var AppConfig *Config
@@ -70,6 +137,33 @@ func LoadConfig() (*Config, error) {
 
 	expireHours, _ := strconv.Atoi(getEnv("JWT_EXPIRE_HOURS", "24"))
 	maxUploadSize, _ := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE", "5242880"), 10, 64)
+	imageMaxDimension, _ := strconv.Atoi(getEnv("IMAGE_MAX_DIMENSION", "1600"))
+	jpegQuality, _ := strconv.Atoi(getEnv("IMAGE_JPEG_QUALITY", "85"))
+	signedURLTTL, _ := strconv.Atoi(getEnv("MEDIA_SIGNED_URL_TTL_MINUTES", "15"))
+	jwtSecret := getEnv("JWT_SECRET", "your-super-secret-jwt-key")
+	// Media URLs are signed with their own secret so rotating one secret
+	// never invalidates or weakens the other
+	mediaSigningSecret := getEnv("MEDIA_SIGNING_SECRET", "")
+	if mediaSigningSecret != "" && mediaSigningSecret == jwtSecret {
+		return nil, fmt.Errorf("MEDIA_SIGNING_SECRET must be different from JWT_SECRET")
+	}
+	if mediaSigningSecret == "" {
+		log.Println("MEDIA_SIGNING_SECRET is not set, private media and uploads are disabled")
+	}
+	minTrustLevel, _ := strconv.Atoi(getEnv("MODERATION_MIN_TRUST_LEVEL", "0"))
+	keywordCacheSeconds, _ := strconv.Atoi(getEnv("MODERATION_KEYWORD_CACHE_SECONDS", "60"))
+	trashRetentionDays, _ := strconv.Atoi(getEnv("TRASH_RETENTION_DAYS", "30"))
+	trashPurgeHours, _ := strconv.Atoi(getEnv("TRASH_PURGE_INTERVAL_HOURS", "24"))
+	recommendationLookbackDays, _ := strconv.Atoi(getEnv("RECOMMENDATION_LOOKBACK_DAYS", "180"))
+	recommendationRefreshHours, _ := strconv.Atoi(getEnv("RECOMMENDATION_REFRESH_INTERVAL_HOURS", "6"))
+	recommendationCacheMinutes, _ := strconv.Atoi(getEnv("RECOMMENDATION_CACHE_TTL_MINUTES", "10"))
+	popularityWindowDays, _ := strconv.Atoi(getEnv("POPULARITY_WINDOW_DAYS", "30"))
+	popularityRefreshMinutes, _ := strconv.Atoi(getEnv("POPULARITY_REFRESH_INTERVAL_MINUTES", "60"))
+	viewFlushSeconds, _ := strconv.Atoi(getEnv("VIEW_FLUSH_INTERVAL_SECONDS", "30"))
+	if viewFlushSeconds <= 0 {
+		viewFlushSeconds = 30
+	}
+	viewDedupeMinutes, _ := strconv.Atoi(getEnv("VIEW_DEDUPE_MINUTES", "30"))
 
 	config := &Config{
 		Database: DatabaseConfig{
@@ -80,15 +174,52 @@ func LoadConfig() (*Config, error) {
 			DBName:   getEnv("DB_NAME", "evermos"),
 		},
 		JWT: JWTConfig{
-			Secret:      getEnv("JWT_SECRET", "your-super-secret-jwt-key"),
+			Secret:      jwtSecret,
 			ExpireHours: expireHours,
 		},
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8000"),
 		},
 		Upload: UploadConfig{
-			Path:          getEnv("UPLOAD_PATH", "./uploads"),
-			MaxUploadSize: maxUploadSize,
+			Driver:            getEnv("STORAGE_DRIVER", "local"),
+			Path:              getEnv("UPLOAD_PATH", "./uploads"),
+			MaxUploadSize:     maxUploadSize,
+			ImageMaxDimension: imageMaxDimension,
+			ThumbnailSizes:    getEnvIntList("IMAGE_THUMBNAIL_SIZES", "150,400,800"),
+			JPEGQuality:       jpegQuality,
+			S3: S3Config{
+				Endpoint:     getEnv("S3_ENDPOINT", ""),
+				Region:       getEnv("S3_REGION", "us-east-1"),
+				Bucket:       getEnv("S3_BUCKET", ""),
+				AccessKey:    getEnv("S3_ACCESS_KEY", ""),
+				SecretKey:    getEnv("S3_SECRET_KEY", ""),
+				UsePathStyle: getEnv("S3_USE_PATH_STYLE", "true") == "true",
+			},
+		},
+		Media: MediaConfig{
+			PublicBaseURL:   getEnv("MEDIA_PUBLIC_BASE_URL", ""),
+			SigningSecret:   mediaSigningSecret,
+			SignedURLTTL:    time.Duration(signedURLTTL) * time.Minute,
+			PrivatePrefixes: getEnvList("MEDIA_PRIVATE_PREFIXES", "private/"),
+		},
+		Moderation: ModerationConfig{
+			MinTrustLevel:   minTrustLevel,
+			KeywordCacheTTL: time.Duration(keywordCacheSeconds) * time.Second,
+		},
+		Trash: TrashConfig{
+			RetentionDays: trashRetentionDays,
+			PurgeInterval: time.Duration(trashPurgeHours) * time.Hour,
+		},
+		Recommendation: RecommendationConfig{
+			LookbackDays:    recommendationLookbackDays,
+			RefreshInterval: time.Duration(recommendationRefreshHours) * time.Hour,
+			CacheTTL:        time.Duration(recommendationCacheMinutes) * time.Minute,
+		},
+		Popularity: PopularityConfig{
+			WindowDays:        popularityWindowDays,
+			RefreshInterval:   time.Duration(popularityRefreshMinutes) * time.Minute,
+			ViewFlushInterval: time.Duration(viewFlushSeconds) * time.Second,
+			ViewDedupeWindow:  time.Duration(viewDedupeMinutes) * time.Minute,
 		},
 	}
 
@@ -113,3 +244,23 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, part := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value := strings.TrimSpace(part); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvIntList(key, defaultValue string) []int {
+	var values []int
+	for _, part := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && value > 0 {
+			values = append(values, value)
+		}
+	}
+	return values
+}
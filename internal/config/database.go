@@ -11,6 +11,7 @@
 // - File ini menginisialisasi koneksi MySQL menggunakan GORM
 // - Menjalankan auto migration untuk semua model
 // - Membuat unique index untuk field notelp pada tabel users
+// - Membuat unique index slug produk (per toko) dan toko setelah backfill
 //
 // ============================================================================
 
@@ -48,6 +49,18 @@ func InitDB(cfg *DatabaseConfig) (*gorm.DB, error) {
 func AutoMigrate(db *gorm.DB) error {
 	log.Println("Running auto migration...")
 
+	// Many-to-many join tables use their own models
+	if err := db.SetupJoinTable(&model.Produk{}, "Tags", &model.ProdukTag{}); err != nil {
+		log.Printf("Warning during join table setup: %v", err)
+	}
+	if err := db.SetupJoinTable(&model.Produk{}, "Categories", &model.ProdukCategory{}); err != nil {
+		log.Printf("Warning during join table setup: %v", err)
+	}
+
+	// Transactions created before payment status existed were complete on
+	// creation, they are marked paid once the column is added
+	legacyTrx := db.Migrator().HasTable(&model.Trx{}) && !db.Migrator().HasColumn(&model.Trx{}, "status_bayar")
+
 	// Migrate each model individually to handle errors gracefully
 	models := []interface{}{
 		&model.User{},
@@ -59,6 +72,26 @@ func AutoMigrate(db *gorm.DB) error {
 		&model.LogProduk{},
 		&model.Trx{},
 		&model.DetailTrx{},
+		&model.Review{},
+		&model.FotoReview{},
+		&model.SlugRedirect{},
+		&model.BannedKeyword{},
+		&model.Notification{},
+		&model.CategoryAttribute{},
+		&model.ProdukAttribute{},
+		&model.Tag{},
+		&model.ProdukTag{},
+		&model.ProdukCategory{},
+		&model.ProdukPromo{},
+		&model.PriceHistory{},
+		&model.BundleItem{},
+		&model.StockSubscription{},
+		&model.ProdukCoPurchase{},
+		&model.ProdukViewDaily{},
+		&model.Wishlist{},
+		&model.DownloadGrant{},
+		&model.RetainedDigitalFile{},
+		&model.TokoFollower{},
 	}
 
 	for _, m := range models {
@@ -102,6 +135,66 @@ func AutoMigrate(db *gorm.DB) error {
 		}
 	}
 
+	// LogProduk snapshots must outlive purged products
+	if db.Migrator().HasConstraint(&model.LogProduk{}, "fk_log_produk_produk") {
+		log.Println("Dropping foreign key: fk_log_produk_produk")
+		if err := db.Migrator().DropConstraint(&model.LogProduk{}, "fk_log_produk_produk"); err != nil {
+			log.Printf("Warning: Failed to drop foreign key: %v", err)
+		}
+	}
+
+	// Products published before scheduling existed have no publish_at, the
+	// feed orders by it so they take their creation time
+	if err := db.Exec(
+		"UPDATE produk SET publish_at = COALESCE(created_at, NOW()) WHERE status = ? AND publish_at IS NULL",
+		model.ProdukStatusPublished,
+	).Error; err != nil {
+		log.Printf("Warning: Failed to backfill publish_at: %v", err)
+	}
+
+	if legacyTrx {
+		log.Println("Marking existing transactions as paid")
+		if err := db.Exec(
+			"UPDATE trx SET status_bayar = ?, paid_at = COALESCE(created_at, NOW())",
+			model.TrxStatusPaid,
+		).Error; err != nil {
+			log.Printf("Warning: Failed to backfill status_bayar: %v", err)
+		}
+	}
+
 	log.Println("Auto migration completed successfully")
 	return nil
 }
+
+// CreateSlugIndexes replaces the plain slug indexes with unique ones, it runs
+// after slugs are backfilled so duplicates left by older rows are gone
+func CreateSlugIndexes(db *gorm.DB) {
+	indexes := []struct {
+		model   interface{}
+		table   string
+		old     string
+		name    string
+		columns string
+	}{
+		{model: &model.Produk{}, table: "produk", old: "idx_produk_toko_slug", name: model.SlugIndexProduk, columns: "id_toko, slug"},
+		{model: &model.Toko{}, table: "toko", old: "idx_toko_slug", name: model.SlugIndexToko, columns: "slug"},
+	}
+
+	for _, idx := range indexes {
+		if !db.Migrator().HasIndex(idx.model, idx.name) {
+			log.Printf("Creating unique index: %s", idx.name)
+			if err := db.Exec("CREATE UNIQUE INDEX " + idx.name + " ON " + idx.table + "(" + idx.columns + ")").Error; err != nil {
+				log.Printf("Warning: Failed to create unique index: %v", err)
+				continue
+			}
+		}
+
+		// The plain index is only dropped once the unique one is in place
+		if db.Migrator().HasIndex(idx.model, idx.old) {
+			log.Printf("Dropping old index: %s", idx.old)
+			if err := db.Migrator().DropIndex(idx.model, idx.old); err != nil {
+				log.Printf("Warning: Failed to drop old index: %v", err)
+			}
+		}
+	}
+}
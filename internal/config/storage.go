@@ -0,0 +1,44 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : storage.go
+// Description  : Inisialisasi storage backend untuk file upload
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini memilih storage backend berdasarkan STORAGE_DRIVER
+// - Driver "local" menyimpan file di UPLOAD_PATH
+// - Driver "s3" menyimpan file di bucket S3-compatible (mis. MinIO)
+//
+// ============================================================================
+
+package config
+
+import (
+	"evermos-api/internal/storage"
+	"fmt"
+	"log"
+)
+
+// InitStorage initializes storage backend for the given driver
+func InitStorage(cfg *UploadConfig, driver string) (storage.Storage, error) {
+	switch driver {
+	case "", "local":
+		log.Printf("Using local storage at %s", cfg.Path)
+		return storage.NewLocalStorage(cfg.Path)
+	case "s3":
+		log.Printf("Using s3 storage bucket %s at %s", cfg.S3.Bucket, cfg.S3.Endpoint)
+		return storage.NewS3Storage(storage.S3Options{
+			Endpoint:     cfg.S3.Endpoint,
+			Region:       cfg.S3.Region,
+			Bucket:       cfg.S3.Bucket,
+			AccessKey:    cfg.S3.AccessKey,
+			SecretKey:    cfg.S3.SecretKey,
+			UsePathStyle: cfg.S3.UsePathStyle,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", driver)
+	}
+}
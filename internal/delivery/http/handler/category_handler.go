@@ -52,6 +52,23 @@ func (h *CategoryHandler) GetAllCategory(c *gin.Context) {
 	))
 }
 
+// GetCategoryTree gets all categories as a nested tree
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	tree, err := h.categoryUsecase.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		tree,
+	))
+}
+
 // GetCategoryByID gets category by ID
 func (h *CategoryHandler) GetCategoryByID(c *gin.Context) {
 	idParam := c.Param("id")
@@ -140,6 +157,41 @@ func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	))
 }
 
+// MoveCategory moves category under another parent or to the root (admin only)
+func (h *CategoryHandler) MoveCategory(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid category ID"},
+		))
+		return
+	}
+
+	var req model.MoveCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.categoryUsecase.MoveCategory(id, req.ParentID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
 // DeleteCategory deletes category (admin only)
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	idParam := c.Param("id")
@@ -165,3 +217,144 @@ func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 		"",
 	))
 }
+
+// GetCategoryAttributes gets the attribute schema of a category, including
+// attributes inherited from parent categories
+func (h *CategoryHandler) GetCategoryAttributes(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid category ID"},
+		))
+		return
+	}
+
+	attributes, err := h.categoryUsecase.GetCategoryAttributes(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		attributes,
+	))
+}
+
+// CreateCategoryAttribute adds an attribute to a category schema (admin only)
+func (h *CategoryHandler) CreateCategoryAttribute(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid category ID"},
+		))
+		return
+	}
+
+	var req model.CategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	attributeID, err := h.categoryUsecase.CreateCategoryAttribute(id, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		attributeID,
+	))
+}
+
+// UpdateCategoryAttribute updates an attribute of a category schema (admin only)
+func (h *CategoryHandler) UpdateCategoryAttribute(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid category ID"},
+		))
+		return
+	}
+
+	attributeID, err := strconv.Atoi(c.Param("attr_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid attribute ID"},
+		))
+		return
+	}
+
+	var req model.CategoryAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.categoryUsecase.UpdateCategoryAttribute(id, attributeID, req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// DeleteCategoryAttribute removes an attribute from a category schema (admin only)
+func (h *CategoryHandler) DeleteCategoryAttribute(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid category ID"},
+		))
+		return
+	}
+
+	attributeID, err := strconv.Atoi(c.Param("attr_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid attribute ID"},
+		))
+		return
+	}
+
+	if err := h.categoryUsecase.DeleteCategoryAttribute(id, attributeID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
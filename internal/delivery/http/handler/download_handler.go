@@ -0,0 +1,102 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : download_handler.go
+// Description  : Handler untuk unduhan produk digital
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint daftar hak unduh transaksi milik user
+// - Pembeli meminta link unduh yang berupa signed URL berlaku singkat
+// - Setiap link yang dibuat mengurangi sisa unduhan
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DownloadHandler handles download endpoints
+type DownloadHandler struct {
+	downloadUsecase usecase.DownloadUsecase
+}
+
+// NewDownloadHandler creates new download handler
+func NewDownloadHandler(downloadUsecase usecase.DownloadUsecase) *DownloadHandler {
+	return &DownloadHandler{downloadUsecase: downloadUsecase}
+}
+
+// GetTrxDownloads gets the digital downloads of a transaction
+func (h *DownloadHandler) GetTrxDownloads(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid transaction ID"},
+		))
+		return
+	}
+
+	grants, err := h.downloadUsecase.GetTrxDownloads(id, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		grants,
+	))
+}
+
+// CreateDownloadLink creates a signed download link, using one download
+func (h *DownloadHandler) CreateDownloadLink(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid transaction ID"},
+		))
+		return
+	}
+
+	grantID, err := strconv.Atoi(c.Param("grant_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid download ID"},
+		))
+		return
+	}
+
+	link, err := h.downloadUsecase.CreateDownloadLink(id, grantID, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		link,
+	))
+}
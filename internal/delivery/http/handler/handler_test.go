@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"evermos-api/internal/delivery/http/handler"
 	"evermos-api/internal/model"
+	"evermos-api/internal/storage"
+	"evermos-api/internal/utils"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -65,6 +68,36 @@ func (m *MockCategoryUsecase) DeleteCategory(id int) error {
 	return args.Error(0)
 }
 
+func (m *MockCategoryUsecase) GetCategoryTree() ([]model.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *MockCategoryUsecase) MoveCategory(id int, parentID *int) error {
+	args := m.Called(id, parentID)
+	return args.Error(0)
+}
+
+func (m *MockCategoryUsecase) GetCategoryAttributes(id int) ([]model.CategoryAttribute, error) {
+	args := m.Called(id)
+	return args.Get(0).([]model.CategoryAttribute), args.Error(1)
+}
+
+func (m *MockCategoryUsecase) CreateCategoryAttribute(id int, req model.CategoryAttributeRequest) (int, error) {
+	args := m.Called(id, req)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryUsecase) UpdateCategoryAttribute(id, attributeID int, req model.CategoryAttributeRequest) error {
+	args := m.Called(id, attributeID, req)
+	return args.Error(0)
+}
+
+func (m *MockCategoryUsecase) DeleteCategoryAttribute(id, attributeID int) error {
+	args := m.Called(id, attributeID)
+	return args.Error(0)
+}
+
 func TestAuthHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -224,3 +257,69 @@ func TestCategoryHandler(t *testing.T) {
 		mockCategoryUsecase.AssertExpectations(t)
 	})
 }
+
+func TestMediaHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	store, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	assert.NoError(t, store.Put("produk/a.jpg", []byte("image"), "image/jpeg"))
+	assert.NoError(t, store.Put("private/b.jpg", []byte("secret"), "image/jpeg"))
+
+	utils.Media = utils.MediaOptions{
+		SigningSecret:   "test-secret",
+		SignedURLTTL:    time.Minute,
+		PrivatePrefixes: []string{"private/"},
+	}
+
+	mediaHandler := handler.NewMediaHandler(store)
+	r := gin.New()
+	r.GET("/media/*key", mediaHandler.ServeMedia)
+
+	t.Run("Serve Public File With Cache Headers", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", utils.MediaURL("produk/a.jpg"), nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image", w.Body.String())
+		assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+		assert.NotEmpty(t, w.Header().Get("ETag"))
+
+		// Conditional request with the returned ETag
+		req, _ = http.NewRequest("GET", "/media/produk/a.jpg", nil)
+		req.Header.Set("If-None-Match", w.Header().Get("ETag"))
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotModified, w.Code)
+	})
+
+	t.Run("Private File Requires Signature", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/media/private/b.jpg", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		req, _ = http.NewRequest("GET", utils.MediaURL("private/b.jpg"), nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "secret", w.Body.String())
+	})
+
+	t.Run("Private File Refused Without Signing Secret", func(t *testing.T) {
+		signed := utils.MediaURL("private/b.jpg")
+		utils.Media.SigningSecret = ""
+		defer func() { utils.Media.SigningSecret = "test-secret" }()
+
+		req, _ := http.NewRequest("GET", signed, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Empty(t, w.Header().Get("Cache-Control"))
+	})
+}
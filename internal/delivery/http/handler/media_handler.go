@@ -0,0 +1,156 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : media_handler.go
+// Description  : Handler untuk menyajikan file upload (media)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini menyajikan file dari storage backend melalui /media/<key>
+// - Mendukung ETag, Last-Modified, dan cache header jangka panjang
+// - File privat hanya dapat diakses dengan signed URL yang belum kadaluarsa
+//
+// ============================================================================
+
+package handler
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/storage"
+	"evermos-api/internal/utils"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaHandler handles media endpoints
+type MediaHandler struct {
+	store storage.Storage
+}
+
+// NewMediaHandler creates new media handler
+func NewMediaHandler(store storage.Storage) *MediaHandler {
+	return &MediaHandler{store: store}
+}
+
+// ServeMedia serves an uploaded file with cache validation headers
+func (h *MediaHandler) ServeMedia(c *gin.Context) {
+	key := storage.CleanKey(c.Param("key"))
+	if key == "" {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"file not found"},
+		))
+		return
+	}
+
+	// Private files are refused outright while no signing secret is set
+	private := utils.IsPrivateMedia(key)
+	if private && !utils.MediaSigningEnabled() {
+		c.JSON(http.StatusForbidden, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"private media is not available"},
+		))
+		return
+	}
+	if private && !utils.VerifyMediaSignature(key, c.Query("expires"), c.Query("signature")) {
+		c.JSON(http.StatusForbidden, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"invalid or expired signature"},
+		))
+		return
+	}
+
+	info, err := h.store.Stat(key)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, storage.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	etag := mediaETag(info)
+	header := c.Writer.Header()
+	header.Set("ETag", etag)
+	if !info.ModTime.IsZero() {
+		header.Set("Last-Modified", info.ModTime.UTC().Format(http.TimeFormat))
+	}
+
+	// Upload filenames are unique hashes, so public files never change
+	if private {
+		maxAge := int64(0)
+		if expires, err := strconv.ParseInt(c.Query("expires"), 10, 64); err == nil {
+			maxAge = expires - time.Now().Unix()
+		}
+		header.Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	} else {
+		header.Set("Cache-Control", "public, max-age=31536000, immutable")
+	}
+
+	if notModified(c.Request, etag, info.ModTime) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	header.Set("Content-Type", storage.ContentType(key))
+	header.Set("Content-Length", strconv.FormatInt(info.Size, 10))
+
+	if c.Request.Method == http.MethodHead {
+		c.Status(http.StatusOK)
+		return
+	}
+
+	reader, err := h.store.Get(key)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+	defer reader.Close()
+
+	c.Status(http.StatusOK)
+	_, _ = io.Copy(c.Writer, reader)
+}
+
+// mediaETag derives a strong ETag from object identity and version
+func mediaETag(info *storage.ObjectInfo) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%d", info.Key, info.Size, info.ModTime.UnixNano())))
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// notModified evaluates If-None-Match and If-Modified-Since conditions
+func notModified(r *http.Request, etag string, modTime time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := r.Header.Get("If-Modified-Since"); since != "" && !modTime.IsZero() {
+		if t, err := http.ParseTime(since); err == nil {
+			return !modTime.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : moderation_handler.go
+// Description  : Handler untuk moderasi produk oleh admin
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint antrian moderasi, approve, dan reject produk
+// - Mengelola daftar kata terlarang dan trust level toko
+// - Semua endpoint hanya untuk admin
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationHandler handles moderation endpoints
+type ModerationHandler struct {
+	moderationUsecase usecase.ModerationUsecase
+}
+
+// NewModerationHandler creates new moderation handler
+func NewModerationHandler(moderationUsecase usecase.ModerationUsecase) *ModerationHandler {
+	return &ModerationHandler{moderationUsecase: moderationUsecase}
+}
+
+// GetModerationQueue gets products by moderation status (default pending)
+func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.moderationUsecase.GetModerationQueue(c.Query("status"), params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// ApproveProduk approves a product
+func (h *ModerationHandler) ApproveProduk(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.moderationUsecase.ApproveProduk(id); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// RejectProduk rejects a product with a reason
+func (h *ModerationHandler) RejectProduk(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	var req model.RejectProdukRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.moderationUsecase.RejectProduk(id, req.Reason); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// GetBannedKeywords gets all banned keywords
+func (h *ModerationHandler) GetBannedKeywords(c *gin.Context) {
+	keywords, err := h.moderationUsecase.GetBannedKeywords()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		keywords,
+	))
+}
+
+// CreateBannedKeyword adds a banned keyword
+func (h *ModerationHandler) CreateBannedKeyword(c *gin.Context) {
+	var req model.BannedKeywordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	id, err := h.moderationUsecase.CreateBannedKeyword(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		id,
+	))
+}
+
+// DeleteBannedKeyword removes a banned keyword
+func (h *ModerationHandler) DeleteBannedKeyword(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid keyword ID"},
+		))
+		return
+	}
+
+	if err := h.moderationUsecase.DeleteBannedKeyword(id); err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
+
+// SetTokoTrustLevel updates the trust level of a toko
+func (h *ModerationHandler) SetTokoTrustLevel(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid toko ID"},
+		))
+		return
+	}
+
+	var req model.TokoTrustRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.moderationUsecase.SetTokoTrustLevel(id, req.TrustLevel); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
@@ -0,0 +1,84 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : notification_handler.go
+// Description  : Handler untuk notifikasi user
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint untuk melihat dan menandai notifikasi
+// - User hanya dapat mengakses notifikasi miliknya sendiri
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler handles notification endpoints
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+// NewNotificationHandler creates new notification handler
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUsecase: notificationUsecase}
+}
+
+// GetMyNotifications gets current user's notifications with pagination
+func (h *NotificationHandler) GetMyNotifications(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.notificationUsecase.GetMyNotifications(userID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// MarkAsRead marks a notification as read
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid notification ID"},
+		))
+		return
+	}
+
+	if err := h.notificationUsecase.MarkAsRead(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
@@ -0,0 +1,86 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : popularity_handler.go
+// Description  : Handler untuk tracking view dan produk trending
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi middleware pencatat view detail produk dan endpoint trending
+// - View dicatat setelah response sukses, viewer diidentifikasi dari user login
+//   atau dari IP dan User-Agent untuk pengunjung anonim
+//
+// ============================================================================
+
+package handler
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PopularityHandler handles popularity endpoints
+type PopularityHandler struct {
+	popularityUsecase usecase.PopularityUsecase
+}
+
+// NewPopularityHandler creates new popularity handler
+func NewPopularityHandler(popularityUsecase usecase.PopularityUsecase) *PopularityHandler {
+	return &PopularityHandler{popularityUsecase: popularityUsecase}
+}
+
+// TrackView records a view of the product in the :id param once the wrapped
+// handler served it successfully
+func (h *PopularityHandler) TrackView(c *gin.Context) {
+	c.Next()
+
+	if c.Writer.Status() != http.StatusOK {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return
+	}
+
+	h.popularityUsecase.RecordView(id, viewerKey(c))
+}
+
+// GetTrending gets the trending products of the last days
+func (h *PopularityHandler) GetTrending(c *gin.Context) {
+	days, _ := strconv.Atoi(c.Query("days"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	produks, err := h.popularityUsecase.GetTrending(days, limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		produks,
+	))
+}
+
+// viewerKey identifies the viewer by user ID, or by a hash of the client IP
+// and User-Agent for anonymous sessions
+func viewerKey(c *gin.Context) string {
+	if userID := middleware.GetUserID(c); userID > 0 {
+		return "user:" + strconv.Itoa(userID)
+	}
+
+	sum := sha1.Sum([]byte(c.ClientIP() + "|" + c.Request.UserAgent()))
+	return "anon:" + hex.EncodeToString(sum[:])
+}
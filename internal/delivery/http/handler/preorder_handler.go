@@ -0,0 +1,86 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : preorder_handler.go
+// Description  : Handler untuk pre-order toko
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint daftar pre-order toko milik user
+// - Query overdue=true hanya menampilkan pre-order yang lewat ship_by
+// - Penjual menandai item pre-order sebagai sudah dikirim
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreorderHandler handles preorder endpoints
+type PreorderHandler struct {
+	preorderUsecase usecase.PreorderUsecase
+}
+
+// NewPreorderHandler creates new preorder handler
+func NewPreorderHandler(preorderUsecase usecase.PreorderUsecase) *PreorderHandler {
+	return &PreorderHandler{preorderUsecase: preorderUsecase}
+}
+
+// GetMyPreorders gets unshipped pre-orders of current user's toko
+func (h *PreorderHandler) GetMyPreorders(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+	overdue := c.Query("overdue") == "true"
+
+	result, err := h.preorderUsecase.GetMyPreorders(userID, overdue, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// MarkShipped marks a pre-order item as shipped
+func (h *PreorderHandler) MarkShipped(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid pre-order ID"},
+		))
+		return
+	}
+
+	if err := h.preorderUsecase.MarkShipped(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
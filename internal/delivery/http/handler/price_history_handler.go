@@ -0,0 +1,90 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : price_history_handler.go
+// Description  : Handler untuk riwayat harga dan analitik harga produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint riwayat harga produk (publik)
+// - Analitik harga hanya untuk pemilik produk
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceHistoryHandler handles price history endpoints
+type PriceHistoryHandler struct {
+	priceHistoryUsecase usecase.PriceHistoryUsecase
+}
+
+// NewPriceHistoryHandler creates new price history handler
+func NewPriceHistoryHandler(priceHistoryUsecase usecase.PriceHistoryUsecase) *PriceHistoryHandler {
+	return &PriceHistoryHandler{priceHistoryUsecase: priceHistoryUsecase}
+}
+
+// GetPriceHistory gets the price changes of a product
+func (h *PriceHistoryHandler) GetPriceHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	history, err := h.priceHistoryUsecase.GetPriceHistory(id, middleware.GetUserID(c))
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		history,
+	))
+}
+
+// GetPriceAnalytics gets units sold per price period of the user's product
+func (h *PriceHistoryHandler) GetPriceAnalytics(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	analytics, err := h.priceHistoryUsecase.GetPriceAnalytics(id, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		analytics,
+	))
+}
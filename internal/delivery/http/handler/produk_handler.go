@@ -17,32 +17,38 @@
 package handler
 
 import (
+	"errors"
 	"evermos-api/internal/delivery/middleware"
 	"evermos-api/internal/model"
+	"evermos-api/internal/storage"
 	"evermos-api/internal/usecase"
 	"evermos-api/internal/utils"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ProdukHandler handles produk endpoints
 type ProdukHandler struct {
-	produkUsecase usecase.ProdukUsecase
-	uploadPath    string
+	produkUsecase      usecase.ProdukUsecase
+	store              storage.Storage
+	trashRetentionDays int
 }
 
 // NewProdukHandler creates new produk handler
-func NewProdukHandler(produkUsecase usecase.ProdukUsecase, uploadPath string) *ProdukHandler {
+func NewProdukHandler(produkUsecase usecase.ProdukUsecase, store storage.Storage, trashRetentionDays int) *ProdukHandler {
 	return &ProdukHandler{
-		produkUsecase: produkUsecase,
-		uploadPath:    uploadPath,
+		produkUsecase:      produkUsecase,
+		store:              store,
+		trashRetentionDays: trashRetentionDays,
 	}
 }
 
-// GetAllProduk gets all produk with pagination and filters
+// GetAllProduk gets all produk with pagination (page/limit or cursor) and filters
 func (h *ProdukHandler) GetAllProduk(c *gin.Context) {
 	params := utils.GetPaginationParams(c)
 
@@ -63,8 +69,30 @@ func (h *ProdukHandler) GetAllProduk(c *gin.Context) {
 	if maxHarga := c.Query("max_harga"); maxHarga != "" {
 		filters["max_harga"] = maxHarga
 	}
+	if tag := c.Query("tag"); tag != "" {
+		filters["tag"] = tag
+	}
+	for name, value := range c.QueryMap("attr") {
+		filters["attr."+name] = value
+	}
+	if sort := c.Query("sort"); sort != "" {
+		if sort != model.ProdukSortPopular {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse(
+				"Failed to GET data",
+				[]string{"Invalid sort, supported: popular"},
+			))
+			return
+		}
+		filters["sort"] = sort
+	}
 
-	result, err := h.produkUsecase.GetAllProduk(params.Limit, params.Offset, filters)
+	var result *model.PaginatedResponse
+	var err error
+	if params.UseCursor {
+		result, err = h.produkUsecase.GetAllProdukByCursor(params.Limit, params.Cursor, filters, middleware.GetUserID(c))
+	} else {
+		result, err = h.produkUsecase.GetAllProduk(params.Limit, params.Offset, filters, middleware.GetUserID(c))
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to GET data",
@@ -91,7 +119,7 @@ func (h *ProdukHandler) GetProdukByID(c *gin.Context) {
 		return
 	}
 
-	produk, err := h.produkUsecase.GetProdukByID(id)
+	produk, err := h.produkUsecase.GetProdukByID(id, middleware.GetUserID(c))
 	if err != nil {
 		c.JSON(http.StatusNotFound, model.ErrorResponse(
 			"Failed to GET data",
@@ -106,6 +134,39 @@ func (h *ProdukHandler) GetProdukByID(c *gin.Context) {
 	))
 }
 
+// GetProdukBySlug gets produk by slug, old slugs redirect to the current one
+func (h *ProdukHandler) GetProdukBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	tokoSlug := c.Query("toko")
+
+	produk, err := h.produkUsecase.GetProdukBySlug(slug, tokoSlug, middleware.GetUserID(c))
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, usecase.ErrAmbiguousSlug) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if produk.Slug != slug || (tokoSlug != "" && produk.Toko != nil && produk.Toko.Slug != tokoSlug) {
+		location := "/api/v1/product/slug/" + produk.Slug
+		if tokoSlug != "" && produk.Toko != nil {
+			location += "?toko=" + url.QueryEscape(produk.Toko.Slug)
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		produk,
+	))
+}
+
 // CreateProduk creates new produk
 func (h *ProdukHandler) CreateProduk(c *gin.Context) {
 	userID := middleware.GetUserID(c)
@@ -123,7 +184,7 @@ func (h *ProdukHandler) CreateProduk(c *gin.Context) {
 	form, _ := c.MultipartForm()
 	files := form.File["photos"]
 
-	id, err := h.produkUsecase.CreateProduk(userID, req, files, h.uploadPath)
+	id, err := h.produkUsecase.CreateProduk(userID, req, files, h.store)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to POST data",
@@ -168,7 +229,7 @@ func (h *ProdukHandler) UpdateProduk(c *gin.Context) {
 		files = form.File["photos"]
 	}
 
-	if err := h.produkUsecase.UpdateProduk(id, userID, req, files, h.uploadPath); err != nil {
+	if err := h.produkUsecase.UpdateProduk(id, userID, req, files, h.store); err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to UPDATE data",
 			[]string{err.Error()},
@@ -196,7 +257,170 @@ func (h *ProdukHandler) DeleteProduk(c *gin.Context) {
 		return
 	}
 
-	if err := h.produkUsecase.DeleteProduk(id, userID); err != nil {
+	if err := h.produkUsecase.DeleteProduk(id, userID, h.store); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		"",
+	))
+}
+
+// AddProdukPhotos appends photos to produk gallery
+func (h *ProdukHandler) AddProdukPhotos(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	form, _ := c.MultipartForm()
+	var files []*multipart.FileHeader
+	if form != nil {
+		files = form.File["photos"]
+	}
+
+	photos, err := h.produkUsecase.AddProdukPhotos(id, userID, files, h.store)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		photos,
+	))
+}
+
+// DeleteProdukPhoto deletes a single produk photo
+func (h *ProdukHandler) DeleteProdukPhoto(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	photoID, err := strconv.Atoi(c.Param("photo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid photo ID"},
+		))
+		return
+	}
+
+	if err := h.produkUsecase.DeleteProdukPhoto(id, photoID, userID, h.store); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
+
+// ReorderProdukPhotos sets produk photos position
+func (h *ProdukHandler) ReorderProdukPhotos(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	var req model.ReorderFotoProdukRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.produkUsecase.ReorderProdukPhotos(id, userID, req.PhotoIDs); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// SetProdukCoverPhoto marks a photo as produk cover
+func (h *ProdukHandler) SetProdukCoverPhoto(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	photoID, err := strconv.Atoi(c.Param("photo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid photo ID"},
+		))
+		return
+	}
+
+	if err := h.produkUsecase.SetProdukCoverPhoto(id, photoID, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// GetMyProduk gets current user's produk including drafts, filterable by status
+func (h *ProdukHandler) GetMyProduk(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.produkUsecase.GetMyProduk(userID, params.Limit, params.Offset, c.Query("status"))
+	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to GET data",
 			[]string{err.Error()},
@@ -206,6 +430,150 @@ func (h *ProdukHandler) DeleteProduk(c *gin.Context) {
 
 	c.JSON(http.StatusOK, model.SuccessResponse(
 		"Succeed to GET data",
+		result,
+	))
+}
+
+// PublishProduk publishes produk now or at the requested publish_at
+func (h *ProdukHandler) PublishProduk(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	// Body is optional, without it the product is published immediately
+	var req model.PublishProdukRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse(
+				"Failed to UPDATE data",
+				[]string{err.Error()},
+			))
+			return
+		}
+	}
+
+	if err := h.produkUsecase.PublishProduk(id, userID, req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
 		"",
 	))
 }
+
+// UnpublishProduk moves produk back to draft
+func (h *ProdukHandler) UnpublishProduk(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.produkUsecase.UnpublishProduk(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// GetTrashProduk gets current user's soft-deleted produk
+func (h *ProdukHandler) GetTrashProduk(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.produkUsecase.GetTrashProduk(userID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// RestoreProduk restores soft-deleted produk
+func (h *ProdukHandler) RestoreProduk(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.produkUsecase.RestoreProduk(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// PurgeDeletedProduk hard-deletes produk soft-deleted more than
+// older_than_days ago (defaults to the configured retention)
+func (h *ProdukHandler) PurgeDeletedProduk(c *gin.Context) {
+	days := h.trashRetentionDays
+	if value := c.Query("older_than_days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse(
+				"Failed to DELETE data",
+				[]string{"Invalid older_than_days"},
+			))
+			return
+		}
+		days = parsed
+	}
+
+	purged, err := h.produkUsecase.PurgeDeletedProduk(time.Now().AddDate(0, 0, -days), h.store)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		gin.H{"purged": purged},
+	))
+}
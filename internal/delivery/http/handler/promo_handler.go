@@ -0,0 +1,137 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : promo_handler.go
+// Description  : Handler untuk flash sale dan harga promo terjadwal
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint jadwal promo produk untuk penjual
+// - Penjual hanya dapat mengelola promo produk dari toko miliknya
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PromoHandler handles produk promo endpoints
+type PromoHandler struct {
+	promoUsecase usecase.PromoUsecase
+}
+
+// NewPromoHandler creates new promo handler
+func NewPromoHandler(promoUsecase usecase.PromoUsecase) *PromoHandler {
+	return &PromoHandler{promoUsecase: promoUsecase}
+}
+
+// GetProdukPromos gets all promos of the user's product
+func (h *PromoHandler) GetProdukPromos(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	promos, err := h.promoUsecase.GetProdukPromos(id, userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		promos,
+	))
+}
+
+// CreateProdukPromo schedules a promo price for the user's product
+func (h *PromoHandler) CreateProdukPromo(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	var req model.CreateProdukPromoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	promoID, err := h.promoUsecase.CreateProdukPromo(id, userID, req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		promoID,
+	))
+}
+
+// DeleteProdukPromo cancels a promo of the user's product
+func (h *PromoHandler) DeleteProdukPromo(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	promoID, err := strconv.Atoi(c.Param("promo_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid promo ID"},
+		))
+		return
+	}
+
+	if err := h.promoUsecase.DeleteProdukPromo(id, promoID, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
@@ -0,0 +1,80 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : recommendation_handler.go
+// Description  : Handler untuk rekomendasi produk terkait
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint produk terkait (also bought dan serupa)
+// - Admin dapat memicu perhitungan ulang co-purchase di luar job berkala
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecommendationHandler handles recommendation endpoints
+type RecommendationHandler struct {
+	recommendationUsecase usecase.RecommendationUsecase
+}
+
+// NewRecommendationHandler creates new recommendation handler
+func NewRecommendationHandler(recommendationUsecase usecase.RecommendationUsecase) *RecommendationHandler {
+	return &RecommendationHandler{recommendationUsecase: recommendationUsecase}
+}
+
+// GetRelatedProduk gets products related to a product
+func (h *RecommendationHandler) GetRelatedProduk(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	related, err := h.recommendationUsecase.GetRelatedProduk(id, limit)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		related,
+	))
+}
+
+// RefreshCoPurchases recomputes co-purchase pairs immediately
+func (h *RecommendationHandler) RefreshCoPurchases(c *gin.Context) {
+	pairs, err := h.recommendationUsecase.RefreshCoPurchases()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		gin.H{"pairs": pairs},
+	))
+}
@@ -0,0 +1,180 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : review_handler.go
+// Description  : Handler untuk review dan rating produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint untuk review produk
+// - Pembeli dapat membuat review dengan foto untuk item yang dibeli
+// - Penjual dapat membalas, admin dapat menyembunyikan review
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/storage"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler handles review endpoints
+type ReviewHandler struct {
+	reviewUsecase usecase.ReviewUsecase
+	store         storage.Storage
+}
+
+// NewReviewHandler creates new review handler
+func NewReviewHandler(reviewUsecase usecase.ReviewUsecase, store storage.Storage) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUsecase: reviewUsecase,
+		store:         store,
+	}
+}
+
+// GetProdukReviews gets visible reviews of a produk with pagination
+func (h *ReviewHandler) GetProdukReviews(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.reviewUsecase.GetReviewsByProdukID(id, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// CreateReview creates review for a purchased item
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	var req model.CreateReviewRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	// Handle optional photo uploads
+	form, _ := c.MultipartForm()
+	var files []*multipart.FileHeader
+	if form != nil {
+		files = form.File["photos"]
+	}
+
+	id, err := h.reviewUsecase.CreateReview(userID, req, files, h.store)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		id,
+	))
+}
+
+// ReplyReview replies a review (toko owner only)
+func (h *ReviewHandler) ReplyReview(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid review ID"},
+		))
+		return
+	}
+
+	var req model.ReplyReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.reviewUsecase.ReplyReview(id, userID, req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// HideReview hides or unhides a review (admin only)
+func (h *ReviewHandler) HideReview(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid review ID"},
+		))
+		return
+	}
+
+	var req model.HideReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.reviewUsecase.SetReviewHidden(id, req.Hidden); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
@@ -0,0 +1,111 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : stock_subscription_handler.go
+// Description  : Handler untuk langganan notifikasi stok tersedia kembali
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint subscribe/unsubscribe stok produk yang habis
+// - User hanya melihat langganan miliknya sendiri
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StockSubscriptionHandler handles stock subscription endpoints
+type StockSubscriptionHandler struct {
+	subscriptionUsecase usecase.StockSubscriptionUsecase
+}
+
+// NewStockSubscriptionHandler creates new stock subscription handler
+func NewStockSubscriptionHandler(subscriptionUsecase usecase.StockSubscriptionUsecase) *StockSubscriptionHandler {
+	return &StockSubscriptionHandler{subscriptionUsecase: subscriptionUsecase}
+}
+
+// Subscribe subscribes the user to back in stock notifications of a product
+func (h *StockSubscriptionHandler) Subscribe(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.subscriptionUsecase.Subscribe(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		"",
+	))
+}
+
+// Unsubscribe removes the user's back in stock subscription of a product
+func (h *StockSubscriptionHandler) Unsubscribe(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.subscriptionUsecase.Unsubscribe(id, userID); err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
+
+// GetMySubscriptions gets current user's back in stock subscriptions
+func (h *StockSubscriptionHandler) GetMySubscriptions(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.subscriptionUsecase.GetMySubscriptions(userID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
@@ -0,0 +1,147 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : tag_handler.go
+// Description  : Handler untuk tag produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint tag cloud (publik)
+// - Rename, merge, dan hapus tag hanya untuk admin
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler handles tag endpoints
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+// NewTagHandler creates new tag handler
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{tagUsecase: tagUsecase}
+}
+
+// GetTagCloud gets the most used tags with their product counts
+func (h *TagHandler) GetTagCloud(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	tags, err := h.tagUsecase.GetTagCloud(limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		tags,
+	))
+}
+
+// RenameTag renames a tag (admin only)
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid tag ID"},
+		))
+		return
+	}
+
+	var req model.RenameTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.tagUsecase.RenameTag(id, req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// MergeTag merges a tag into another tag (admin only)
+func (h *TagHandler) MergeTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid tag ID"},
+		))
+		return
+	}
+
+	var req model.MergeTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	if err := h.tagUsecase.MergeTag(id, req); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
+
+// DeleteTag deletes a tag and removes it from all products (admin only)
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid tag ID"},
+		))
+		return
+	}
+
+	if err := h.tagUsecase.DeleteTag(id); err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
@@ -0,0 +1,132 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : toko_follower_handler.go
+// Description  : Handler untuk follow toko dan feed produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint follow/unfollow toko dan daftar toko diikuti
+// - Feed produk dari toko yang diikuti selalu memakai cursor pagination,
+//   parameter cursor kosong atau tidak ada berarti halaman pertama
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TokoFollowerHandler handles toko follower endpoints
+type TokoFollowerHandler struct {
+	followerUsecase usecase.TokoFollowerUsecase
+}
+
+// NewTokoFollowerHandler creates new toko follower handler
+func NewTokoFollowerHandler(followerUsecase usecase.TokoFollowerUsecase) *TokoFollowerHandler {
+	return &TokoFollowerHandler{followerUsecase: followerUsecase}
+}
+
+// FollowToko follows a toko as current user
+func (h *TokoFollowerHandler) FollowToko(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id_toko"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid toko ID"},
+		))
+		return
+	}
+
+	if err := h.followerUsecase.FollowToko(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		"",
+	))
+}
+
+// UnfollowToko unfollows a toko as current user
+func (h *TokoFollowerHandler) UnfollowToko(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id_toko"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid toko ID"},
+		))
+		return
+	}
+
+	if err := h.followerUsecase.UnfollowToko(id, userID); err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
+
+// GetFollowing gets tokos followed by current user
+func (h *TokoFollowerHandler) GetFollowing(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.followerUsecase.GetFollowing(userID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
+
+// GetFeed gets the newest products of tokos followed by current user
+func (h *TokoFollowerHandler) GetFeed(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.followerUsecase.GetFeed(userID, params.Limit, params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
@@ -19,6 +19,7 @@ package handler
 import (
 	"evermos-api/internal/delivery/middleware"
 	"evermos-api/internal/model"
+	"evermos-api/internal/storage"
 	"evermos-api/internal/usecase"
 	"evermos-api/internal/utils"
 	"net/http"
@@ -30,14 +31,14 @@ import (
 // TokoHandler handles toko endpoints
 type TokoHandler struct {
 	tokoUsecase usecase.TokoUsecase
-	uploadPath  string
+	store       storage.Storage
 }
 
 // NewTokoHandler creates new toko handler
-func NewTokoHandler(tokoUsecase usecase.TokoUsecase, uploadPath string) *TokoHandler {
+func NewTokoHandler(tokoUsecase usecase.TokoUsecase, store storage.Storage) *TokoHandler {
 	return &TokoHandler{
 		tokoUsecase: tokoUsecase,
-		uploadPath:  uploadPath,
+		store:       store,
 	}
 }
 
@@ -60,8 +61,10 @@ func (h *TokoHandler) GetMyToko(c *gin.Context) {
 	))
 }
 
-// GetTokoByID gets toko by ID
+// GetTokoByID gets toko by ID, is_followed is set for an authenticated user
 func (h *TokoHandler) GetTokoByID(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
 	idParam := c.Param("id_toko")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
@@ -72,7 +75,27 @@ func (h *TokoHandler) GetTokoByID(c *gin.Context) {
 		return
 	}
 
-	toko, err := h.tokoUsecase.GetTokoByID(id)
+	toko, err := h.tokoUsecase.GetTokoByID(id, userID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		toko,
+	))
+}
+
+// GetTokoBySlug gets toko by slug, old slugs redirect to the current one
+func (h *TokoHandler) GetTokoBySlug(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	slug := c.Param("slug")
+
+	toko, err := h.tokoUsecase.GetTokoBySlug(slug, userID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, model.ErrorResponse(
 			"Failed to GET data",
@@ -81,18 +104,29 @@ func (h *TokoHandler) GetTokoByID(c *gin.Context) {
 		return
 	}
 
+	if toko.Slug != slug {
+		c.Redirect(http.StatusMovedPermanently, "/api/v1/toko/slug/"+toko.Slug)
+		return
+	}
+
 	c.JSON(http.StatusOK, model.SuccessResponse(
 		"Succeed to GET data",
 		toko,
 	))
 }
 
-// GetAllToko gets all toko with pagination
+// GetAllToko gets all toko with pagination (page/limit or cursor)
 func (h *TokoHandler) GetAllToko(c *gin.Context) {
 	params := utils.GetPaginationParams(c)
 	nama := c.Query("nama")
 
-	result, err := h.tokoUsecase.GetAllToko(params.Limit, params.Offset, nama)
+	var result *model.PaginatedResponse
+	var err error
+	if params.UseCursor {
+		result, err = h.tokoUsecase.GetAllTokoByCursor(params.Limit, params.Cursor, nama)
+	} else {
+		result, err = h.tokoUsecase.GetAllToko(params.Limit, params.Offset, nama)
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to GET data",
@@ -130,10 +164,11 @@ func (h *TokoHandler) UpdateToko(c *gin.Context) {
 		return
 	}
 
-	// Handle file upload
-	file, _ := c.FormFile("photo")
+	// Handle file uploads
+	photo, _ := c.FormFile("photo")
+	banner, _ := c.FormFile("banner")
 
-	if err := h.tokoUsecase.UpdateToko(tokoID, userID, req.NamaToko, file, h.uploadPath); err != nil {
+	if err := h.tokoUsecase.UpdateToko(tokoID, userID, req, photo, banner, h.store); err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
 			"Failed to UPDATE data",
 			[]string{err.Error()},
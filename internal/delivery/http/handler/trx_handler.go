@@ -11,6 +11,7 @@
 // - File ini berisi endpoint untuk CRUD transaksi
 // - Mendukung pembuatan transaksi dengan multiple produk
 // - Otomatis generate nomor invoice
+// - Admin mengonfirmasi pembayaran transaksi
 //
 // ============================================================================
 
@@ -42,6 +43,24 @@ func (h *TrxHandler) GetAllTrx(c *gin.Context) {
 	userID := middleware.GetUserID(c)
 	params := utils.GetPaginationParams(c)
 
+	// Cursor mode returns a paginated envelope with next/prev cursors
+	if params.UseCursor {
+		result, err := h.trxUsecase.GetAllTrxByCursor(userID, params.Limit, params.Cursor)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse(
+				"Failed to GET data",
+				[]string{err.Error()},
+			))
+			return
+		}
+
+		c.JSON(http.StatusOK, model.SuccessResponse(
+			"Succeed to GET data",
+			result,
+		))
+		return
+	}
+
 	trxs, err := h.trxUsecase.GetAllTrx(userID, params.Limit, params.Offset)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, model.ErrorResponse(
@@ -113,3 +132,28 @@ func (h *TrxHandler) CreateTrx(c *gin.Context) {
 		id,
 	))
 }
+
+// MarkTrxPaid confirms the payment of a transaction
+func (h *TrxHandler) MarkTrxPaid(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{"Invalid transaction ID"},
+		))
+		return
+	}
+
+	if err := h.trxUsecase.MarkTrxPaid(id); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to UPDATE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to UPDATE data",
+		"",
+	))
+}
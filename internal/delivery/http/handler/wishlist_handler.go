@@ -0,0 +1,111 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : wishlist_handler.go
+// Description  : Handler untuk wishlist (produk favorit) user
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi endpoint tambah, hapus, dan daftar wishlist
+// - User hanya melihat wishlist miliknya sendiri
+//
+// ============================================================================
+
+package handler
+
+import (
+	"evermos-api/internal/delivery/middleware"
+	"evermos-api/internal/model"
+	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WishlistHandler handles wishlist endpoints
+type WishlistHandler struct {
+	wishlistUsecase usecase.WishlistUsecase
+}
+
+// NewWishlistHandler creates new wishlist handler
+func NewWishlistHandler(wishlistUsecase usecase.WishlistUsecase) *WishlistHandler {
+	return &WishlistHandler{wishlistUsecase: wishlistUsecase}
+}
+
+// AddToWishlist saves a product to current user's wishlist
+func (h *WishlistHandler) AddToWishlist(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.wishlistUsecase.AddToWishlist(id, userID); err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to POST data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to POST data",
+		"",
+	))
+}
+
+// RemoveFromWishlist removes a product from current user's wishlist
+func (h *WishlistHandler) RemoveFromWishlist(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{"Invalid product ID"},
+		))
+		return
+	}
+
+	if err := h.wishlistUsecase.RemoveFromWishlist(id, userID); err != nil {
+		c.JSON(http.StatusNotFound, model.ErrorResponse(
+			"Failed to DELETE data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to DELETE data",
+		"",
+	))
+}
+
+// GetMyWishlist gets current user's wishlist with product data
+func (h *WishlistHandler) GetMyWishlist(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	params := utils.GetPaginationParams(c)
+
+	result, err := h.wishlistUsecase.GetMyWishlist(userID, params.Limit, params.Offset)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse(
+			"Failed to GET data",
+			[]string{err.Error()},
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse(
+		"Succeed to GET data",
+		result,
+	))
+}
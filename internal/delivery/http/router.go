@@ -25,15 +25,29 @@ import (
 
 // Router sets up all routes
 type Router struct {
-	authHandler     *handler.AuthHandler
-	userHandler     *handler.UserHandler
-	tokoHandler     *handler.TokoHandler
-	alamatHandler   *handler.AlamatHandler
-	categoryHandler *handler.CategoryHandler
-	produkHandler   *handler.ProdukHandler
-	trxHandler      *handler.TrxHandler
-	wilayahHandler  *handler.WilayahHandler
-	jwtSecret       string
+	authHandler         *handler.AuthHandler
+	userHandler         *handler.UserHandler
+	tokoHandler         *handler.TokoHandler
+	alamatHandler       *handler.AlamatHandler
+	categoryHandler     *handler.CategoryHandler
+	produkHandler       *handler.ProdukHandler
+	trxHandler          *handler.TrxHandler
+	wilayahHandler      *handler.WilayahHandler
+	reviewHandler       *handler.ReviewHandler
+	mediaHandler        *handler.MediaHandler
+	moderationHandler   *handler.ModerationHandler
+	notificationHandler *handler.NotificationHandler
+	tagHandler          *handler.TagHandler
+	promoHandler        *handler.PromoHandler
+	priceHistoryHandler *handler.PriceHistoryHandler
+	stockHandler        *handler.StockSubscriptionHandler
+	preorderHandler     *handler.PreorderHandler
+	recommendHandler    *handler.RecommendationHandler
+	popularityHandler   *handler.PopularityHandler
+	wishlistHandler     *handler.WishlistHandler
+	downloadHandler     *handler.DownloadHandler
+	followerHandler     *handler.TokoFollowerHandler
+	jwtSecret           string
 }
 
 // NewRouter creates new router
@@ -46,18 +60,46 @@ func NewRouter(
 	produkHandler *handler.ProdukHandler,
 	trxHandler *handler.TrxHandler,
 	wilayahHandler *handler.WilayahHandler,
+	reviewHandler *handler.ReviewHandler,
+	mediaHandler *handler.MediaHandler,
+	moderationHandler *handler.ModerationHandler,
+	notificationHandler *handler.NotificationHandler,
+	tagHandler *handler.TagHandler,
+	promoHandler *handler.PromoHandler,
+	priceHistoryHandler *handler.PriceHistoryHandler,
+	stockHandler *handler.StockSubscriptionHandler,
+	preorderHandler *handler.PreorderHandler,
+	recommendHandler *handler.RecommendationHandler,
+	popularityHandler *handler.PopularityHandler,
+	wishlistHandler *handler.WishlistHandler,
+	downloadHandler *handler.DownloadHandler,
+	followerHandler *handler.TokoFollowerHandler,
 	jwtSecret string,
 ) *Router {
 	return &Router{
-		authHandler:     authHandler,
-		userHandler:     userHandler,
-		tokoHandler:     tokoHandler,
-		alamatHandler:   alamatHandler,
-		categoryHandler: categoryHandler,
-		produkHandler:   produkHandler,
-		trxHandler:      trxHandler,
-		wilayahHandler:  wilayahHandler,
-		jwtSecret:       jwtSecret,
+		authHandler:         authHandler,
+		userHandler:         userHandler,
+		tokoHandler:         tokoHandler,
+		alamatHandler:       alamatHandler,
+		categoryHandler:     categoryHandler,
+		produkHandler:       produkHandler,
+		trxHandler:          trxHandler,
+		wilayahHandler:      wilayahHandler,
+		reviewHandler:       reviewHandler,
+		mediaHandler:        mediaHandler,
+		moderationHandler:   moderationHandler,
+		notificationHandler: notificationHandler,
+		tagHandler:          tagHandler,
+		promoHandler:        promoHandler,
+		priceHistoryHandler: priceHistoryHandler,
+		stockHandler:        stockHandler,
+		preorderHandler:     preorderHandler,
+		recommendHandler:    recommendHandler,
+		popularityHandler:   popularityHandler,
+		wishlistHandler:     wishlistHandler,
+		downloadHandler:     downloadHandler,
+		followerHandler:     followerHandler,
+		jwtSecret:           jwtSecret,
 	}
 }
 
@@ -86,6 +128,10 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 		})
 	})
 
+	// Media routes (uploaded files)
+	router.GET("/media/*key", r.mediaHandler.ServeMedia)
+	router.HEAD("/media/*key", r.mediaHandler.ServeMedia)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -100,46 +146,104 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 		category := v1.Group("/category")
 		{
 			category.GET("", r.categoryHandler.GetAllCategory)
+			category.GET("/tree", r.categoryHandler.GetCategoryTree)
 			category.GET("/:id", r.categoryHandler.GetCategoryByID)
+			category.GET("/:id/attributes", r.categoryHandler.GetCategoryAttributes)
 
 			// Admin only
 			categoryAdmin := category.Use(middleware.AuthMiddleware(r.jwtSecret), middleware.AdminMiddleware())
 			{
 				categoryAdmin.POST("", r.categoryHandler.CreateCategory)
 				categoryAdmin.PUT("/:id", r.categoryHandler.UpdateCategory)
+				categoryAdmin.PUT("/:id/move", r.categoryHandler.MoveCategory)
 				categoryAdmin.DELETE("/:id", r.categoryHandler.DeleteCategory)
+
+				// Attribute schema
+				categoryAdmin.POST("/:id/attributes", r.categoryHandler.CreateCategoryAttribute)
+				categoryAdmin.PUT("/:id/attributes/:attr_id", r.categoryHandler.UpdateCategoryAttribute)
+				categoryAdmin.DELETE("/:id/attributes/:attr_id", r.categoryHandler.DeleteCategoryAttribute)
 			}
 		}
 
+		// Tag routes (public)
+		v1.GET("/tag", r.tagHandler.GetTagCloud)
+
 		// Toko routes
 		toko := v1.Group("/toko")
 		{
 			toko.GET("", r.tokoHandler.GetAllToko)
-			toko.GET("/:id_toko", r.tokoHandler.GetTokoByID)
+			toko.GET("/:id_toko", middleware.OptionalAuthMiddleware(r.jwtSecret), r.tokoHandler.GetTokoByID)
+			toko.GET("/slug/:slug", middleware.OptionalAuthMiddleware(r.jwtSecret), r.tokoHandler.GetTokoBySlug)
 
 			// Authenticated routes
 			tokoAuth := toko.Use(middleware.AuthMiddleware(r.jwtSecret))
 			{
 				tokoAuth.GET("/my", r.tokoHandler.GetMyToko)
+				tokoAuth.GET("/my/preorder", r.preorderHandler.GetMyPreorders)
+				tokoAuth.PUT("/my/preorder/:id/shipped", r.preorderHandler.MarkShipped)
 				tokoAuth.PUT("/:id_toko", r.tokoHandler.UpdateToko)
+
+				// Followers
+				tokoAuth.POST("/:id_toko/follow", r.followerHandler.FollowToko)
+				tokoAuth.DELETE("/:id_toko/follow", r.followerHandler.UnfollowToko)
 			}
 		}
 
 		// Product routes
 		product := v1.Group("/product")
 		{
-			product.GET("", r.produkHandler.GetAllProduk)
-			product.GET("/:id", r.produkHandler.GetProdukByID)
+			product.GET("", middleware.OptionalAuthMiddleware(r.jwtSecret), r.produkHandler.GetAllProduk)
+			product.GET("/trending", r.popularityHandler.GetTrending)
+			product.GET("/:id", middleware.OptionalAuthMiddleware(r.jwtSecret), r.popularityHandler.TrackView, r.produkHandler.GetProdukByID)
+			product.GET("/slug/:slug", middleware.OptionalAuthMiddleware(r.jwtSecret), r.produkHandler.GetProdukBySlug)
+			product.GET("/:id/reviews", r.reviewHandler.GetProdukReviews)
+			product.GET("/:id/related", r.recommendHandler.GetRelatedProduk)
+			product.GET("/:id/price-history", middleware.OptionalAuthMiddleware(r.jwtSecret), r.priceHistoryHandler.GetPriceHistory)
 
 			// Authenticated routes
 			productAuth := product.Use(middleware.AuthMiddleware(r.jwtSecret))
 			{
+				productAuth.GET("/my", r.produkHandler.GetMyProduk)
+				productAuth.GET("/trash", r.produkHandler.GetTrashProduk)
 				productAuth.POST("", r.produkHandler.CreateProduk)
 				productAuth.PUT("/:id", r.produkHandler.UpdateProduk)
 				productAuth.DELETE("/:id", r.produkHandler.DeleteProduk)
+				productAuth.PUT("/:id/restore", r.produkHandler.RestoreProduk)
+
+				// Publishing workflow
+				productAuth.PUT("/:id/publish", r.produkHandler.PublishProduk)
+				productAuth.PUT("/:id/unpublish", r.produkHandler.UnpublishProduk)
+
+				// Photo management
+				productAuth.POST("/:id/photos", r.produkHandler.AddProdukPhotos)
+				productAuth.PUT("/:id/photos/order", r.produkHandler.ReorderProdukPhotos)
+				productAuth.PUT("/:id/photos/:photo_id/cover", r.produkHandler.SetProdukCoverPhoto)
+				productAuth.DELETE("/:id/photos/:photo_id", r.produkHandler.DeleteProdukPhoto)
+
+				// Back in stock subscription
+				productAuth.POST("/:id/stock-subscription", r.stockHandler.Subscribe)
+				productAuth.DELETE("/:id/stock-subscription", r.stockHandler.Unsubscribe)
+
+				// Price analytics
+				productAuth.GET("/:id/price-analytics", r.priceHistoryHandler.GetPriceAnalytics)
+
+				// Promo / flash sale schedule
+				productAuth.GET("/:id/promo", r.promoHandler.GetProdukPromos)
+				productAuth.POST("/:id/promo", r.promoHandler.CreateProdukPromo)
+				productAuth.DELETE("/:id/promo/:promo_id", r.promoHandler.DeleteProdukPromo)
 			}
 		}
 
+		// Review routes (authenticated)
+		review := v1.Group("/review").Use(middleware.AuthMiddleware(r.jwtSecret))
+		{
+			review.POST("", r.reviewHandler.CreateReview)
+			review.PUT("/:id/reply", r.reviewHandler.ReplyReview)
+
+			// Admin only
+			review.PUT("/:id/hide", middleware.AdminMiddleware(), r.reviewHandler.HideReview)
+		}
+
 		// User routes (authenticated)
 		user := v1.Group("/user").Use(middleware.AuthMiddleware(r.jwtSecret))
 		{
@@ -152,6 +256,51 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 			user.POST("/alamat", r.alamatHandler.CreateAlamat)
 			user.PUT("/alamat/:id", r.alamatHandler.UpdateAlamat)
 			user.DELETE("/alamat/:id", r.alamatHandler.DeleteAlamat)
+
+			// Notification routes
+			user.GET("/notification", r.notificationHandler.GetMyNotifications)
+			user.PUT("/notification/:id/read", r.notificationHandler.MarkAsRead)
+
+			// Back in stock subscriptions
+			user.GET("/stock-subscription", r.stockHandler.GetMySubscriptions)
+
+			// Wishlist
+			user.GET("/wishlist", r.wishlistHandler.GetMyWishlist)
+			user.POST("/wishlist/:id", r.wishlistHandler.AddToWishlist)
+			user.DELETE("/wishlist/:id", r.wishlistHandler.RemoveFromWishlist)
+
+			// Followed tokos and their product feed
+			user.GET("/following", r.followerHandler.GetFollowing)
+			user.GET("/feed", r.followerHandler.GetFeed)
+		}
+
+		// Admin routes (admin only)
+		admin := v1.Group("/admin").Use(middleware.AuthMiddleware(r.jwtSecret), middleware.AdminMiddleware())
+		{
+			// Product moderation
+			admin.GET("/product/moderation", r.moderationHandler.GetModerationQueue)
+			admin.PUT("/product/:id/approve", r.moderationHandler.ApproveProduk)
+			admin.PUT("/product/:id/reject", r.moderationHandler.RejectProduk)
+
+			// Trash retention
+			admin.DELETE("/product/trash", r.produkHandler.PurgeDeletedProduk)
+			admin.POST("/product/related/refresh", r.recommendHandler.RefreshCoPurchases)
+
+			// Banned keywords
+			admin.GET("/banned-keyword", r.moderationHandler.GetBannedKeywords)
+			admin.POST("/banned-keyword", r.moderationHandler.CreateBannedKeyword)
+			admin.DELETE("/banned-keyword/:id", r.moderationHandler.DeleteBannedKeyword)
+
+			// Tag management
+			admin.PUT("/tag/:id", r.tagHandler.RenameTag)
+			admin.POST("/tag/:id/merge", r.tagHandler.MergeTag)
+			admin.DELETE("/tag/:id", r.tagHandler.DeleteTag)
+
+			// Toko trust level
+			admin.PUT("/toko/:id/trust", r.moderationHandler.SetTokoTrustLevel)
+
+			// Payment confirmation
+			admin.PUT("/trx/:id/paid", r.trxHandler.MarkTrxPaid)
 		}
 
 		// Transaction routes (authenticated)
@@ -160,6 +309,10 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 			trx.GET("", r.trxHandler.GetAllTrx)
 			trx.GET("/:id", r.trxHandler.GetTrxByID)
 			trx.POST("", r.trxHandler.CreateTrx)
+
+			// Digital product downloads
+			trx.GET("/:id/downloads", r.downloadHandler.GetTrxDownloads)
+			trx.POST("/:id/downloads/:grant_id", r.downloadHandler.CreateDownloadLink)
 		}
 
 		// Wilayah routes (public)
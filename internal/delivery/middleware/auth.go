@@ -71,6 +71,23 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware sets user info when a valid token is sent but lets
+// anonymous requests through
+func OptionalAuthMiddleware(jwtSecret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		parts := strings.Split(c.GetHeader("Authorization"), " ")
+		if len(parts) == 2 && parts[0] == "Bearer" {
+			if claims, err := utils.ValidateToken(parts[1], jwtSecret); err == nil {
+				c.Set("userID", claims.UserID)
+				c.Set("email", claims.Email)
+				c.Set("isAdmin", claims.IsAdmin)
+			}
+		}
+
+		c.Next()
+	}
+}
+
 // AdminMiddleware checks if user is admin
 func AdminMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,68 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : attribute.go
+// Description  : Model dan DTO untuk skema atribut kategori dan atribut produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct CategoryAttribute dan ProdukAttribute
+// - Skema atribut diwariskan dari kategori induk ke sub-kategori
+// - Nilai atribut produk disimpan per baris agar bisa difilter
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// Attribute types
+const (
+	AttributeTypeText    = "text"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeDate    = "date"
+	AttributeTypeEnum    = "enum"
+)
+
+// CategoryAttribute represents category_attribute table
+type CategoryAttribute struct {
+	ID            int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDCategory    int        `gorm:"column:id_category;uniqueIndex:idx_category_attribute_name,priority:1" json:"category_id"`
+	Name          string     `gorm:"type:varchar(100);uniqueIndex:idx_category_attribute_name,priority:2" json:"name"`
+	Label         string     `gorm:"type:varchar(255)" json:"label"`
+	Type          string     `gorm:"type:varchar(20)" json:"type"`
+	Required      bool       `gorm:"type:tinyint(1);default:0" json:"required"`
+	AllowedValues []string   `gorm:"column:allowed_values;type:text;serializer:json" json:"allowed_values,omitempty"`
+	CreatedAt     *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt     *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
+	Category      *Category  `gorm:"foreignKey:IDCategory;references:ID" json:"-"`
+}
+
+func (CategoryAttribute) TableName() string {
+	return "category_attribute"
+}
+
+// ProdukAttribute represents produk_attribute table
+type ProdukAttribute struct {
+	ID       int     `gorm:"primaryKey;autoIncrement" json:"-"`
+	IDProduk int     `gorm:"column:id_produk;index" json:"-"`
+	Name     string  `gorm:"type:varchar(100);index:idx_produk_attribute_value,priority:1" json:"name"`
+	Value    string  `gorm:"type:varchar(255);index:idx_produk_attribute_value,priority:2" json:"value"`
+	Produk   *Produk `gorm:"foreignKey:IDProduk;references:ID" json:"-"`
+}
+
+func (ProdukAttribute) TableName() string {
+	return "produk_attribute"
+}
+
+// CategoryAttributeRequest DTO
+type CategoryAttributeRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Label         string   `json:"label"`
+	Type          string   `json:"type" binding:"required,oneof=text number boolean date enum"`
+	Required      bool     `json:"required"`
+	AllowedValues []string `json:"allowed_values"`
+}
@@ -0,0 +1,45 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : bundle.go
+// Description  : Model dan DTO untuk produk bundle (paket)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct BundleItem yang menyusun produk bundle
+// - Stok bundle tidak disimpan, dihitung dari stok produk komponennya
+// - BundleSnapshot menyimpan isi bundle di LogProduk saat transaksi
+//
+// ============================================================================
+
+package model
+
+// BundleItem represents bundle_item table
+type BundleItem struct {
+	ID        int     `gorm:"primaryKey;autoIncrement" json:"-"`
+	IDBundle  int     `gorm:"column:id_bundle;index" json:"-"`
+	IDProduk  int     `gorm:"column:id_produk;index" json:"product_id"`
+	Kuantitas int     `gorm:"type:int" json:"kuantitas"`
+	Component *Produk `gorm:"foreignKey:IDProduk;references:ID" json:"product,omitempty"`
+	Bundle    *Produk `gorm:"foreignKey:IDBundle;references:ID" json:"-"`
+}
+
+func (BundleItem) TableName() string {
+	return "bundle_item"
+}
+
+// BundleSnapshot is a bundle component as sold, stored in LogProduk
+type BundleSnapshot struct {
+	IDProduk      int    `json:"product_id"`
+	NamaProduk    string `json:"nama_produk"`
+	Kuantitas     int    `json:"kuantitas"`
+	HargaKonsumen string `json:"harga_konsumen"`
+}
+
+// BundleItemRequest is one component in the bundle_items form field
+type BundleItemRequest struct {
+	ProductID int `json:"product_id"`
+	Kuantitas int `json:"kuantitas"`
+}
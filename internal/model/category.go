@@ -10,6 +10,7 @@
 // Notes:
 // - File ini berisi struct Category dan request/response DTOs
 // - Category digunakan untuk mengklasifikasikan produk
+// - Category bersifat hierarkis (parent/child) dengan kedalaman bebas
 // - Hanya admin yang dapat mengelola category
 //
 // ============================================================================
@@ -20,10 +21,13 @@ import "time"
 
 // Category represents category table
 type Category struct {
-	ID           int        `gorm:"primaryKey;autoIncrement" json:"id"`
-	NamaCategory string     `gorm:"column:nama_category;type:varchar(255)" json:"nama_category"`
-	CreatedAt    *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
-	UpdatedAt    *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
+	ID               int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	NamaCategory     string     `gorm:"column:nama_category;type:varchar(255)" json:"nama_category"`
+	RequiresApproval bool       `gorm:"column:requires_approval;type:tinyint(1);default:0" json:"requires_approval"`
+	IDParent         *int       `gorm:"column:id_parent;index" json:"parent_id"`
+	Children         []Category `gorm:"-" json:"children,omitempty"`
+	CreatedAt        *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt        *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
 }
 
 func (Category) TableName() string {
@@ -32,5 +36,18 @@ func (Category) TableName() string {
 
 // CategoryRequest DTO
 type CategoryRequest struct {
-	NamaCategory string `json:"nama_category" binding:"required"`
+	NamaCategory     string `json:"nama_category" binding:"required"`
+	RequiresApproval bool   `json:"requires_approval"`
+	ParentID         *int   `json:"parent_id"`
+}
+
+// MoveCategoryRequest DTO, null parent_id moves the category to the root
+type MoveCategoryRequest struct {
+	ParentID *int `json:"parent_id"`
+}
+
+// CategoryBreadcrumb is one step of a category path from the root
+type CategoryBreadcrumb struct {
+	ID           int    `json:"id"`
+	NamaCategory string `json:"nama_category"`
 }
@@ -0,0 +1,67 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : download.go
+// Description  : Model dan DTO untuk hak unduh produk digital
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct DownloadGrant, RetainedDigitalFile dan DownloadLink
+// - DownloadGrant dibuat per DetailTrx produk digital saat transaksi dibuat
+//   tanpa masa berlaku, grant baru aktif (activated_at dan expires_at terisi)
+//   saat transaksi dibayar
+// - Key file disalin ke grant agar tetap bisa diunduh walau produk diubah
+// - Setiap link yang dibuat mengurangi sisa unduhan hingga max_downloads
+// - File digital yang diganti/dihapus tapi masih dipakai grant dicatat di
+//   RetainedDigitalFile dan dihapus job purge setelah tidak dipakai lagi
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// DownloadGrant represents download_grant table
+type DownloadGrant struct {
+	ID           int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDTrx        int        `gorm:"column:id_trx;index" json:"id_trx"`
+	IDDetailTrx  int        `gorm:"column:id_detail_trx;index" json:"id_detail_trx"`
+	IDUser       int        `gorm:"column:id_user;index" json:"-"`
+	IDProduk     int        `gorm:"column:id_produk;index" json:"product_id"`
+	FileKey      string     `gorm:"column:file_key;type:varchar(255);index" json:"-"`
+	FileName     string     `gorm:"column:file_name;type:varchar(255)" json:"file_name"`
+	MaxDownloads int        `gorm:"column:max_downloads" json:"max_downloads"`
+	Downloads    int        `gorm:"column:downloads;default:0;<-:create" json:"downloads"`
+	ValidDays    int        `gorm:"column:valid_days" json:"-"`
+	ActivatedAt  *time.Time `gorm:"column:activated_at;type:datetime" json:"activated_at"`
+	ExpiresAt    *time.Time `gorm:"column:expires_at;type:datetime" json:"expires_at"`
+	CreatedAt    *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+	Trx          *Trx       `gorm:"foreignKey:IDTrx;references:ID" json:"-"`
+	DetailTrx    *DetailTrx `gorm:"foreignKey:IDDetailTrx;references:ID" json:"-"`
+}
+
+func (DownloadGrant) TableName() string {
+	return "download_grant"
+}
+
+// RetainedDigitalFile represents retained_digital_file table, a digital file
+// no product sells anymore that is kept for its download grants
+type RetainedDigitalFile struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	FileKey   string     `gorm:"column:file_key;type:varchar(255);uniqueIndex" json:"file_key"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+}
+
+func (RetainedDigitalFile) TableName() string {
+	return "retained_digital_file"
+}
+
+// DownloadLink DTO, a signed URL for one download of a grant
+type DownloadLink struct {
+	URL                string    `json:"url"`
+	FileName           string    `json:"file_name"`
+	ExpiresAt          time.Time `json:"expires_at"`
+	RemainingDownloads int       `json:"remaining_downloads"`
+}
@@ -0,0 +1,45 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : moderation.go
+// Description  : Model dan DTO untuk moderasi produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct BannedKeyword dan request DTOs moderasi
+// - Kata terlarang dicek pada nama dan deskripsi produk
+// - Hanya admin yang dapat mengelola moderasi
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// BannedKeyword represents banned_keyword table
+type BannedKeyword struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Keyword   string     `gorm:"type:varchar(100);uniqueIndex" json:"keyword"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+}
+
+func (BannedKeyword) TableName() string {
+	return "banned_keyword"
+}
+
+// BannedKeywordRequest DTO
+type BannedKeywordRequest struct {
+	Keyword string `json:"keyword" binding:"required"`
+}
+
+// RejectProdukRequest DTO
+type RejectProdukRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// TokoTrustRequest DTO
+type TokoTrustRequest struct {
+	TrustLevel int `json:"trust_level" binding:"min=0"`
+}
@@ -0,0 +1,45 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : notification.go
+// Description  : Model untuk notifikasi in-app user
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct Notification
+// - Notifikasi dibuat oleh sistem (mis. hasil moderasi produk)
+// - User hanya dapat melihat notifikasi miliknya sendiri
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// Notification types
+const (
+	NotificationProdukApproved = "produk_approved"
+	NotificationProdukRejected = "produk_rejected"
+	NotificationLowStock       = "low_stock"
+	NotificationBackInStock    = "back_in_stock"
+	NotificationPriceDrop      = "price_drop"
+)
+
+// Notification represents notification table
+type Notification struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDUser    int        `gorm:"column:id_user;index" json:"-"`
+	Type      string     `gorm:"type:varchar(50)" json:"type"`
+	Title     string     `gorm:"type:varchar(255)" json:"title"`
+	Message   string     `gorm:"type:text" json:"message"`
+	IDRef     int        `gorm:"column:id_ref" json:"ref_id,omitempty"`
+	IsRead    bool       `gorm:"column:is_read;type:tinyint(1);default:0" json:"is_read"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+	User      *User      `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+}
+
+func (Notification) TableName() string {
+	return "notification"
+}
@@ -0,0 +1,30 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : popularity.go
+// Description  : Model untuk statistik view dan popularitas produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct ProdukViewDaily
+// - View halaman detail produk diagregasi per produk per hari
+// - Skor popularitas disimpan di Produk.PopularityScore oleh job berkala
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// ProdukViewDaily represents produk_view_daily table
+type ProdukViewDaily struct {
+	IDProduk int       `gorm:"column:id_produk;primaryKey;autoIncrement:false" json:"id_produk"`
+	Date     time.Time `gorm:"column:date;type:date;primaryKey" json:"date"`
+	Views    int       `gorm:"column:views" json:"views"`
+}
+
+func (ProdukViewDaily) TableName() string {
+	return "produk_view_daily"
+}
@@ -0,0 +1,65 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : price_history.go
+// Description  : Model dan DTO untuk riwayat harga dan analitik harga produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct PriceHistory yang dicatat setiap harga produk berubah
+// - PriceAnalytics merangkum penjualan per periode harga dari snapshot LogProduk
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// PriceHistory represents price_history table
+type PriceHistory struct {
+	ID            int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDProduk      int       `gorm:"column:id_produk;index:idx_price_history_produk,priority:1" json:"product_id"`
+	HargaReseller string    `gorm:"column:harga_reseller;type:varchar(255)" json:"harga_reseller"`
+	HargaKonsumen string    `gorm:"column:harga_konsumen;type:varchar(255)" json:"harga_konsumen"`
+	ChangedAt     time.Time `gorm:"column:changed_at;type:datetime;index:idx_price_history_produk,priority:2" json:"changed_at"`
+	Produk        *Produk   `gorm:"foreignKey:IDProduk;references:ID;constraint:-" json:"-"`
+}
+
+func (PriceHistory) TableName() string {
+	return "price_history"
+}
+
+// ProdukSale is a single sold order line of a product with its price snapshot,
+// HargaPromo is set when the line was sold at a promo price
+type ProdukSale struct {
+	HargaKonsumen string
+	HargaPromo    *int
+	Kuantitas     int
+	HargaTotal    int
+	SoldAt        time.Time
+}
+
+// PricePeriod summarizes sales while one price was active
+type PricePeriod struct {
+	HargaKonsumen      int        `json:"harga_konsumen"`
+	HargaReseller      int        `json:"harga_reseller"`
+	StartAt            time.Time  `json:"start_at"`
+	EndAt              *time.Time `json:"end_at"`
+	Days               float64    `json:"days"`
+	UnitsSold          int        `json:"units_sold"`
+	PromoUnitsSold     int        `json:"promo_units_sold"`
+	Revenue            int        `json:"revenue"`
+	UnitsPerDay        float64    `json:"units_per_day"`
+	PriceChangePercent *float64   `json:"price_change_percent,omitempty"`
+	UnitsPerDayChange  *float64   `json:"units_per_day_change_percent,omitempty"`
+}
+
+// PriceAnalytics is the seller view of how price changes affected sales
+type PriceAnalytics struct {
+	ProductID    int           `json:"product_id"`
+	TotalUnits   int           `json:"total_units"`
+	TotalRevenue int           `json:"total_revenue"`
+	Periods      []PricePeriod `json:"periods"`
+}
@@ -9,68 +9,163 @@
 //
 // Notes:
 // - File ini berisi struct Produk, FotoProduk, dan LogProduk
-// - Produk dapat memiliki multiple foto
-// - LogProduk menyimpan snapshot produk saat transaksi
+// - Produk dapat memiliki multiple foto dengan urutan dan foto cover
+// - Produk berstatus draft, published, atau scheduled (tampil mulai publish_at)
+// - Produk hanya tampil publik setelah lolos moderasi (moderation_status approved)
+// - Produk dapat memiliki tag bebas dan kategori sekunder selain IDCategory
+// - Promo aktif ditampilkan pada produk dan dicatat di LogProduk saat transaksi
+// - Produk bundle tersusun dari produk komponen, isinya dicatat di LogProduk
+// - Produk pre-order dijual tanpa stok fisik dengan lead time dan quota opsional
+// - Produk digital menyimpan file privat yang hanya bisa diunduh pembeli melalui
+//   signed URL dengan batas jumlah unduhan dan masa berlaku
+// - PopularityScore dihitung berkala dari jumlah view dan penjualan terbaru
+// - is_wishlisted diisi untuk user login, wishlist_count dan low_stock_threshold
+//   hanya untuk pemilik toko (GET /product/my)
+// - LogProduk menyimpan snapshot produk saat transaksi dan tetap ada setelah
+//   produk di-purge (tanpa foreign key ke produk)
 //
 // ============================================================================
 
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"evermos-api/internal/utils"
+	"mime/multipart"
+	"time"
+)
 
 // Produk represents produk table
 type Produk struct {
-	ID            int          `gorm:"primaryKey;autoIncrement" json:"id"`
-	NamaProduk    string       `gorm:"column:nama_produk;type:varchar(255)" json:"nama_produk"`
-	Slug          string       `gorm:"type:varchar(255)" json:"slug"`
-	HargaReseller string       `gorm:"column:harga_reseller;type:varchar(255)" json:"harga_reseler"`
-	HargaKonsumen string       `gorm:"column:harga_konsumen;type:varchar(255)" json:"harga_konsumen"`
-	Stok          int          `gorm:"type:int" json:"stok"`
-	Deskripsi     string       `gorm:"type:text" json:"deskripsi"`
-	CreatedAt     *time.Time   `gorm:"column:created_at;type:date" json:"created_at"`
-	UpdatedAt     *time.Time   `gorm:"column:updated_at;type:date" json:"updated_at"`
-	DeletedAt     *time.Time   `gorm:"column:deleted_at;type:date;index" json:"-"`
-	IDToko        int          `gorm:"column:id_toko;index" json:"-"`
-	IDCategory    int          `gorm:"column:id_category;index" json:"-"`
-	Toko          *Toko        `gorm:"foreignKey:IDToko;references:ID" json:"toko,omitempty"`
-	Category      *Category    `gorm:"foreignKey:IDCategory;references:ID" json:"category,omitempty"`
-	Photos        []FotoProduk `gorm:"foreignKey:IDProduk;references:ID" json:"photos,omitempty"`
+	ID                int                  `gorm:"primaryKey;autoIncrement" json:"id"`
+	NamaProduk        string               `gorm:"column:nama_produk;type:varchar(255)" json:"nama_produk"`
+	Slug              string               `gorm:"type:varchar(255)" json:"slug"`
+	HargaReseller     string               `gorm:"column:harga_reseller;type:varchar(255)" json:"harga_reseler"`
+	HargaKonsumen     string               `gorm:"column:harga_konsumen;type:varchar(255)" json:"harga_konsumen"`
+	Stok              int                  `gorm:"type:int" json:"stok"`
+	LowStockThreshold int                  `gorm:"column:low_stock_threshold;default:0" json:"-"`
+	IsPreorder        bool                 `gorm:"column:is_preorder;type:tinyint(1);default:0" json:"is_preorder"`
+	PreorderLeadDays  int                  `gorm:"column:preorder_lead_days;default:0" json:"preorder_lead_days,omitempty"`
+	PreorderQuota     int                  `gorm:"column:preorder_quota;default:0" json:"preorder_quota,omitempty"`
+	PreorderSold      int                  `gorm:"column:preorder_sold;default:0;<-:create" json:"preorder_sold,omitempty"`
+	DigitalFile       string               `gorm:"column:digital_file;type:varchar(255)" json:"-"`
+	DigitalFileName   string               `gorm:"column:digital_file_name;type:varchar(255)" json:"digital_file_name,omitempty"`
+	DownloadLimit     int                  `gorm:"column:download_limit;default:0" json:"download_limit,omitempty"`
+	DownloadDays      int                  `gorm:"column:download_days;default:0" json:"download_days,omitempty"`
+	Deskripsi         string               `gorm:"type:text" json:"deskripsi"`
+	RatingAvg         float64              `gorm:"column:rating_avg;type:decimal(3,2);default:0;<-:create" json:"rating_avg"`
+	RatingCount       int                  `gorm:"column:rating_count;default:0;<-:create" json:"rating_count"`
+	RatingTotal       int                  `gorm:"column:rating_total;default:0;<-:create" json:"-"`
+	PopularityScore   int                  `gorm:"column:popularity_score;default:0;index;<-:create" json:"popularity_score"`
+	CreatedAt         *time.Time           `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt         *time.Time           `gorm:"column:updated_at;type:date" json:"updated_at"`
+	DeletedAt         *time.Time           `gorm:"column:deleted_at;type:date;index" json:"-"`
+	Type              string               `gorm:"column:type;type:varchar(20);default:'single'" json:"type"`
+	Status            string               `gorm:"column:status;type:varchar(20);default:'published';index" json:"status"`
+	PublishAt         *time.Time           `gorm:"column:publish_at;type:datetime;index;index:idx_produk_toko_publish,priority:2" json:"publish_at,omitempty"`
+	ModerationStatus  string               `gorm:"column:moderation_status;type:varchar(20);default:'approved';index" json:"moderation_status"`
+	ModerationNote    string               `gorm:"column:moderation_note;type:text" json:"moderation_note,omitempty"`
+	IDToko            int                  `gorm:"column:id_toko;index;index:idx_produk_toko_publish,priority:1" json:"-"`
+	IDCategory        int                  `gorm:"column:id_category;index" json:"-"`
+	Toko              *Toko                `gorm:"foreignKey:IDToko;references:ID" json:"toko,omitempty"`
+	Category          *Category            `gorm:"foreignKey:IDCategory;references:ID" json:"category,omitempty"`
+	Breadcrumb        []CategoryBreadcrumb `gorm:"-" json:"breadcrumb,omitempty"`
+	Promo             *PromoPrice          `gorm:"-" json:"promo,omitempty"`
+	IsWishlisted      *bool                `gorm:"-" json:"is_wishlisted,omitempty"`
+	WishlistCount     *int                 `gorm:"-" json:"wishlist_count,omitempty"`
+	SellerThreshold   *int                 `gorm:"-" json:"low_stock_threshold,omitempty"`
+	Photos            []FotoProduk         `gorm:"foreignKey:IDProduk;references:ID" json:"photos,omitempty"`
+	Attributes        []ProdukAttribute    `gorm:"foreignKey:IDProduk;references:ID" json:"attributes,omitempty"`
+	BundleItems       []BundleItem         `gorm:"foreignKey:IDBundle;references:ID" json:"bundle_items,omitempty"`
+	Tags              []Tag                `gorm:"many2many:produk_tag;joinForeignKey:IDProduk;joinReferences:IDTag" json:"tags,omitempty"`
+	Categories        []Category           `gorm:"many2many:produk_category;joinForeignKey:IDProduk;joinReferences:IDCategory" json:"secondary_categories,omitempty"`
 }
 
 func (Produk) TableName() string {
 	return "produk"
 }
 
+// Produk types, bundle stock is derived from its components and digital
+// products are never out of stock
+const (
+	ProdukTypeSingle  = "single"
+	ProdukTypeBundle  = "bundle"
+	ProdukTypeDigital = "digital"
+)
+
+// Produk listing sort orders, the default is by id
+const ProdukSortPopular = "popular"
+
+// Produk visibility status
+const (
+	ProdukStatusDraft     = "draft"
+	ProdukStatusPublished = "published"
+	ProdukStatusScheduled = "scheduled"
+)
+
+// Produk moderation status
+const (
+	ModerationPending  = "pending"
+	ModerationApproved = "approved"
+	ModerationRejected = "rejected"
+)
+
 // FotoProduk represents foto_produk table
 type FotoProduk struct {
-	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
-	IDProduk  int        `gorm:"column:id_produk;index" json:"product_id"`
-	URL       string     `gorm:"type:varchar(255)" json:"url"`
-	UpdatedAt *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
-	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
-	Produk    *Produk    `gorm:"foreignKey:IDProduk;references:ID" json:"-"`
+	ID        int               `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDProduk  int               `gorm:"column:id_produk;index" json:"product_id"`
+	URL       string            `gorm:"type:varchar(255)" json:"url"`
+	Sizes     map[string]string `gorm:"column:sizes;type:text;serializer:json" json:"sizes,omitempty"`
+	Posisi    int               `gorm:"column:posisi;default:0" json:"posisi"`
+	IsCover   bool              `gorm:"column:is_cover;type:tinyint(1);default:0" json:"is_cover"`
+	UpdatedAt *time.Time        `gorm:"column:updated_at;type:date" json:"updated_at"`
+	CreatedAt *time.Time        `gorm:"column:created_at;type:date" json:"created_at"`
+	Produk    *Produk           `gorm:"foreignKey:IDProduk;references:ID" json:"-"`
 }
 
 func (FotoProduk) TableName() string {
 	return "foto_produk"
 }
 
+// MarshalJSON exposes photo URLs as absolute media URLs
+func (f FotoProduk) MarshalJSON() ([]byte, error) {
+	type fotoProduk FotoProduk
+	out := fotoProduk(f)
+	out.URL = utils.MediaURL(f.URL)
+	out.Sizes = utils.MediaURLs(f.Sizes)
+	return json.Marshal(out)
+}
+
+// Files returns stored file paths of the photo including its thumbnails
+func (f FotoProduk) Files() []string {
+	files := []string{f.URL}
+	for _, thumb := range f.Sizes {
+		files = append(files, thumb)
+	}
+	return files
+}
+
 // LogProduk represents log_produk table (snapshot of product at transaction time)
 type LogProduk struct {
-	ID            int        `gorm:"primaryKey;autoIncrement" json:"id"`
-	IDProduk      int        `gorm:"column:id_produk;index" json:"id_produk"`
-	NamaProduk    string     `gorm:"column:nama_produk;type:varchar(255)" json:"nama_produk"`
-	Slug          string     `gorm:"type:varchar(255)" json:"slug"`
-	HargaReseller string     `gorm:"column:harga_reseller;type:varchar(255)" json:"harga_reseller"`
-	HargaKonsumen string     `gorm:"column:harga_konsumen;type:varchar(255)" json:"harga_konsumen"`
-	Deskripsi     string     `gorm:"type:text" json:"deskripsi"`
-	CreatedAt     *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
-	UpdatedAt     *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
-	IDToko        int        `gorm:"column:id_toko;index" json:"id_toko"`
-	IDCategory    int        `gorm:"column:id_category;index" json:"id_category"`
-	Produk        *Produk    `gorm:"foreignKey:IDProduk;references:ID" json:"-"`
-	Toko          *Toko      `gorm:"foreignKey:IDToko;references:ID" json:"-"`
-	Category      *Category  `gorm:"foreignKey:IDCategory;references:ID" json:"-"`
+	ID            int               `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDProduk      int               `gorm:"column:id_produk;index" json:"id_produk"`
+	NamaProduk    string            `gorm:"column:nama_produk;type:varchar(255)" json:"nama_produk"`
+	Slug          string            `gorm:"type:varchar(255)" json:"slug"`
+	HargaReseller string            `gorm:"column:harga_reseller;type:varchar(255)" json:"harga_reseller"`
+	HargaKonsumen string            `gorm:"column:harga_konsumen;type:varchar(255)" json:"harga_konsumen"`
+	Deskripsi     string            `gorm:"type:text" json:"deskripsi"`
+	Attributes    map[string]string `gorm:"column:attributes;type:text;serializer:json" json:"attributes,omitempty"`
+	IDPromo       *int              `gorm:"column:id_promo" json:"id_promo,omitempty"`
+	PromoLabel    string            `gorm:"column:promo_label;type:varchar(100)" json:"promo_label,omitempty"`
+	HargaPromo    *int              `gorm:"column:harga_promo" json:"harga_promo,omitempty"`
+	Bundle        []BundleSnapshot  `gorm:"column:bundle;type:text;serializer:json" json:"bundle,omitempty"`
+	CreatedAt     *time.Time        `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt     *time.Time        `gorm:"column:updated_at;type:date" json:"updated_at"`
+	IDToko        int               `gorm:"column:id_toko;index" json:"id_toko"`
+	IDCategory    int               `gorm:"column:id_category;index" json:"id_category"`
+	Produk        *Produk           `gorm:"foreignKey:IDProduk;references:ID;constraint:-" json:"-"`
+	Toko          *Toko             `gorm:"foreignKey:IDToko;references:ID" json:"-"`
+	Category      *Category         `gorm:"foreignKey:IDCategory;references:ID" json:"-"`
 }
 
 func (LogProduk) TableName() string {
@@ -79,20 +174,55 @@ func (LogProduk) TableName() string {
 
 // CreateProdukRequest DTO
 type CreateProdukRequest struct {
-	NamaProduk    string `form:"nama_produk" binding:"required"`
-	HargaReseller string `form:"harga_reseller" binding:"required"`
-	HargaKonsumen string `form:"harga_konsumen" binding:"required"`
-	Stok          int    `form:"stok" binding:"required"`
-	Deskripsi     string `form:"deskripsi" binding:"required"`
-	CategoryID    int    `form:"category_id" binding:"required"`
+	NamaProduk        string                `form:"nama_produk" binding:"required"`
+	HargaReseller     string                `form:"harga_reseller" binding:"required"`
+	HargaKonsumen     string                `form:"harga_konsumen" binding:"required"`
+	Stok              int                   `form:"stok" binding:"required_unless=Type bundle IsPreorder true Type digital"`
+	Deskripsi         string                `form:"deskripsi" binding:"required"`
+	CategoryID        int                   `form:"category_id" binding:"required"`
+	Type              string                `form:"type" binding:"omitempty,oneof=single bundle digital"`
+	LowStockThreshold int                   `form:"low_stock_threshold" binding:"min=0"`
+	IsPreorder        bool                  `form:"is_preorder"`
+	PreorderLeadDays  int                   `form:"preorder_lead_days" binding:"min=0,max=365"`
+	PreorderQuota     int                   `form:"preorder_quota" binding:"min=0"`
+	DigitalFile       *multipart.FileHeader `form:"digital_file"`
+	DownloadLimit     int                   `form:"download_limit" binding:"min=0,max=100"`
+	DownloadDays      int                   `form:"download_days" binding:"min=0,max=365"`
+	BundleItems       string                `form:"bundle_items"`
+	Status            string                `form:"status" binding:"omitempty,oneof=draft published"`
+	PublishAt         string                `form:"publish_at"`
+	Attributes        string                `form:"attributes"`
+	Tags              string                `form:"tags"`
+	CategoryIDs       string                `form:"secondary_category_ids"`
 }
 
 // UpdateProdukRequest DTO
 type UpdateProdukRequest struct {
-	NamaProduk    string `form:"nama_produk"`
-	HargaReseller string `form:"harga_reseller"`
-	HargaKonsumen string `form:"harga_konsumen"`
-	Stok          int    `form:"stok"`
-	Deskripsi     string `form:"deskripsi"`
-	CategoryID    int    `form:"category_id"`
+	NamaProduk        string                `form:"nama_produk"`
+	HargaReseller     string                `form:"harga_reseller"`
+	HargaKonsumen     string                `form:"harga_konsumen"`
+	Stok              int                   `form:"stok"`
+	Deskripsi         string                `form:"deskripsi"`
+	CategoryID        int                   `form:"category_id"`
+	Attributes        string                `form:"attributes"`
+	Tags              *string               `form:"tags"`
+	CategoryIDs       *string               `form:"secondary_category_ids"`
+	BundleItems       string                `form:"bundle_items"`
+	LowStockThreshold *int                  `form:"low_stock_threshold" binding:"omitempty,min=0"`
+	IsPreorder        *bool                 `form:"is_preorder"`
+	PreorderLeadDays  *int                  `form:"preorder_lead_days" binding:"omitempty,min=0,max=365"`
+	PreorderQuota     *int                  `form:"preorder_quota" binding:"omitempty,min=0"`
+	DigitalFile       *multipart.FileHeader `form:"digital_file"`
+	DownloadLimit     *int                  `form:"download_limit" binding:"omitempty,min=1,max=100"`
+	DownloadDays      *int                  `form:"download_days" binding:"omitempty,min=1,max=365"`
+}
+
+// PublishProdukRequest DTO, empty publish_at publishes immediately
+type PublishProdukRequest struct {
+	PublishAt string `json:"publish_at"`
+}
+
+// ReorderFotoProdukRequest DTO
+type ReorderFotoProdukRequest struct {
+	PhotoIDs []int `json:"photo_ids" binding:"required,min=1"`
 }
@@ -0,0 +1,61 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : promo.go
+// Description  : Model dan DTO untuk harga promo terjadwal (flash sale)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct ProdukPromo dan tampilan promo aktif pada produk
+// - Promo berlaku antara start_at dan end_at, jadwal promo satu produk tidak
+//   boleh saling tumpang tindih
+// - Quota 0 berarti tanpa batas, sold bertambah saat transaksi dibuat
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// ProdukPromo represents produk_promo table
+type ProdukPromo struct {
+	ID         int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDProduk   int        `gorm:"column:id_produk;index:idx_produk_promo_window,priority:1" json:"product_id"`
+	Label      string     `gorm:"type:varchar(100)" json:"label"`
+	PromoPrice int        `gorm:"column:promo_price" json:"promo_price"`
+	StartAt    time.Time  `gorm:"column:start_at;type:datetime;index:idx_produk_promo_window,priority:2" json:"start_at"`
+	EndAt      time.Time  `gorm:"column:end_at;type:datetime" json:"end_at"`
+	Quota      int        `gorm:"column:quota;default:0" json:"quota"`
+	Sold       int        `gorm:"column:sold;default:0" json:"sold"`
+	CreatedAt  *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt  *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
+	Produk     *Produk    `gorm:"foreignKey:IDProduk;references:ID" json:"-"`
+}
+
+func (ProdukPromo) TableName() string {
+	return "produk_promo"
+}
+
+// PromoPrice is the active promo shown on a product response
+type PromoPrice struct {
+	ID              int       `json:"id"`
+	Label           string    `json:"label"`
+	OriginalPrice   int       `json:"original_price"`
+	PromoPrice      int       `json:"promo_price"`
+	DiscountPercent int       `json:"discount_percent"`
+	StartAt         time.Time `json:"start_at"`
+	EndAt           time.Time `json:"end_at"`
+	EndsInSeconds   int64     `json:"ends_in_seconds"`
+	RemainingQuota  *int      `json:"remaining_quota,omitempty"`
+}
+
+// CreateProdukPromoRequest DTO, start_at and end_at use RFC3339
+type CreateProdukPromoRequest struct {
+	Label      string `json:"label"`
+	PromoPrice int    `json:"promo_price" binding:"required,min=1"`
+	StartAt    string `json:"start_at" binding:"required"`
+	EndAt      string `json:"end_at" binding:"required"`
+	Quota      int    `json:"quota" binding:"min=0"`
+}
@@ -0,0 +1,37 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : recommendation.go
+// Description  : Model untuk rekomendasi produk terkait
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct ProdukCoPurchase dan RelatedProducts
+// - ProdukCoPurchase dihitung ulang berkala dari riwayat DetailTrx/LogProduk
+// - Score adalah jumlah transaksi yang memuat kedua produk sekaligus
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// ProdukCoPurchase represents produk_co_purchase table
+type ProdukCoPurchase struct {
+	IDProduk  int        `gorm:"column:id_produk;primaryKey;autoIncrement:false" json:"id_produk"`
+	IDRelated int        `gorm:"column:id_related;primaryKey;autoIncrement:false" json:"id_related"`
+	Score     int        `gorm:"column:score" json:"score"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:datetime" json:"updated_at"`
+}
+
+func (ProdukCoPurchase) TableName() string {
+	return "produk_co_purchase"
+}
+
+// RelatedProducts is the response of the related products endpoint
+type RelatedProducts struct {
+	AlsoBought []Produk `json:"also_bought"`
+	Similar    []Produk `json:"similar"`
+}
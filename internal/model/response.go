@@ -26,9 +26,11 @@ type APIResponse struct {
 
 // PaginatedResponse for list endpoints
 type PaginatedResponse struct {
-	Page  int         `json:"page"`
-	Limit int         `json:"limit"`
-	Data  interface{} `json:"data"`
+	Page       int         `json:"page"`
+	Limit      int         `json:"limit"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
+	Data       interface{} `json:"data"`
 }
 
 // SuccessResponse helper
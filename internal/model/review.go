@@ -0,0 +1,83 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : review.go
+// Description  : Model dan DTO untuk entitas Review produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct Review dan FotoReview
+// - Review terikat pada satu baris DetailTrx (satu review per baris)
+// - Penjual dapat membalas sekali, admin dapat menyembunyikan review
+//
+// ============================================================================
+
+package model
+
+import (
+	"encoding/json"
+	"evermos-api/internal/utils"
+	"time"
+)
+
+// Review represents review table
+type Review struct {
+	ID          int          `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDDetailTrx int          `gorm:"column:id_detail_trx;uniqueIndex" json:"id_detail_trx"`
+	IDProduk    int          `gorm:"column:id_produk;index" json:"id_produk"`
+	IDToko      int          `gorm:"column:id_toko;index" json:"id_toko"`
+	IDUser      int          `gorm:"column:id_user;index" json:"id_user"`
+	Rating      int          `gorm:"type:tinyint" json:"rating"`
+	Ulasan      string       `gorm:"type:text" json:"ulasan"`
+	Balasan     string       `gorm:"type:text" json:"balasan"`
+	BalasanAt   *time.Time   `gorm:"column:balasan_at" json:"balasan_at"`
+	IsHidden    bool         `gorm:"column:is_hidden;type:tinyint(1);default:0" json:"-"`
+	CreatedAt   *time.Time   `gorm:"column:created_at;type:date" json:"created_at"`
+	UpdatedAt   *time.Time   `gorm:"column:updated_at;type:date" json:"updated_at"`
+	User        *User        `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+	DetailTrx   *DetailTrx   `gorm:"foreignKey:IDDetailTrx;references:ID" json:"-"`
+	Photos      []FotoReview `gorm:"foreignKey:IDReview;references:ID" json:"photos,omitempty"`
+}
+
+func (Review) TableName() string {
+	return "review"
+}
+
+// FotoReview represents foto_review table
+type FotoReview struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDReview  int        `gorm:"column:id_review;index" json:"review_id"`
+	URL       string     `gorm:"type:varchar(255)" json:"url"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+}
+
+func (FotoReview) TableName() string {
+	return "foto_review"
+}
+
+// MarshalJSON exposes review photo URL as absolute media URL
+func (f FotoReview) MarshalJSON() ([]byte, error) {
+	type fotoReview FotoReview
+	out := fotoReview(f)
+	out.URL = utils.MediaURL(f.URL)
+	return json.Marshal(out)
+}
+
+// CreateReviewRequest DTO
+type CreateReviewRequest struct {
+	DetailTrxID int    `form:"detail_trx_id" binding:"required"`
+	Rating      int    `form:"rating" binding:"required,min=1,max=5"`
+	Ulasan      string `form:"ulasan"`
+}
+
+// ReplyReviewRequest DTO
+type ReplyReviewRequest struct {
+	Balasan string `json:"balasan" binding:"required"`
+}
+
+// HideReviewRequest DTO
+type HideReviewRequest struct {
+	Hidden bool `json:"hidden"`
+}
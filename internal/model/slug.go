@@ -0,0 +1,47 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : slug.go
+// Description  : Model untuk riwayat slug (redirect slug lama)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct SlugRedirect
+// - Slug lama disimpan saat produk/toko berganti nama
+// - Slug produk unik per toko, slug toko unik global (id_scope = 0)
+// - Unique index slug dibuat setelah backfill agar data lama yang duplikat
+//   tidak menggagalkan migrasi
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// Slug entity types
+const (
+	SlugEntityProduk = "produk"
+	SlugEntityToko   = "toko"
+)
+
+// Unique slug indexes, created by config.CreateSlugIndexes
+const (
+	SlugIndexProduk = "idx_produk_toko_slug_unique"
+	SlugIndexToko   = "idx_toko_slug_unique"
+)
+
+// SlugRedirect represents slug_redirect table
+type SlugRedirect struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Entity    string     `gorm:"type:varchar(20);uniqueIndex:idx_slug_redirect_lookup,priority:1" json:"entity"`
+	IDScope   int        `gorm:"column:id_scope;uniqueIndex:idx_slug_redirect_lookup,priority:2" json:"scope_id"`
+	Slug      string     `gorm:"type:varchar(255);uniqueIndex:idx_slug_redirect_lookup,priority:3" json:"slug"`
+	IDTarget  int        `gorm:"column:id_target;index" json:"target_id"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
+}
+
+func (SlugRedirect) TableName() string {
+	return "slug_redirect"
+}
@@ -0,0 +1,32 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : stock_subscription.go
+// Description  : Model untuk langganan notifikasi stok tersedia kembali
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct StockSubscription
+// - Langganan dihapus setelah notifikasi stok tersedia dikirim
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// StockSubscription represents stock_subscription table
+type StockSubscription struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDUser    int        `gorm:"column:id_user;uniqueIndex:idx_stock_subscription_user_produk,priority:1" json:"-"`
+	IDProduk  int        `gorm:"column:id_produk;uniqueIndex:idx_stock_subscription_user_produk,priority:2;index" json:"product_id"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+	User      *User      `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+	Produk    *Produk    `gorm:"foreignKey:IDProduk;references:ID" json:"product,omitempty"`
+}
+
+func (StockSubscription) TableName() string {
+	return "stock_subscription"
+}
@@ -0,0 +1,70 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : tag.go
+// Description  : Model dan DTO untuk tag produk dan kategori sekunder
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct Tag, ProdukTag, dan ProdukCategory
+// - Tag bersifat bebas, diidentifikasi berdasarkan slug yang unik
+// - ProdukCategory menyimpan kategori sekunder produk selain IDCategory
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// Tag represents tag table
+type Tag struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string     `gorm:"type:varchar(50)" json:"name"`
+	Slug      string     `gorm:"type:varchar(80);uniqueIndex" json:"slug"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at,omitempty"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:date" json:"updated_at,omitempty"`
+}
+
+func (Tag) TableName() string {
+	return "tag"
+}
+
+// ProdukTag represents produk_tag join table
+type ProdukTag struct {
+	IDProduk int `gorm:"column:id_produk;primaryKey"`
+	IDTag    int `gorm:"column:id_tag;primaryKey;index"`
+}
+
+func (ProdukTag) TableName() string {
+	return "produk_tag"
+}
+
+// ProdukCategory represents produk_category join table (secondary categories)
+type ProdukCategory struct {
+	IDProduk   int `gorm:"column:id_produk;primaryKey"`
+	IDCategory int `gorm:"column:id_category;primaryKey;index"`
+}
+
+func (ProdukCategory) TableName() string {
+	return "produk_category"
+}
+
+// TagCount is a tag with the number of visible products using it
+type TagCount struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	ProdukCount int    `json:"produk_count"`
+}
+
+// RenameTagRequest DTO
+type RenameTagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// MergeTagRequest DTO
+type MergeTagRequest struct {
+	TargetID int `json:"target_id" binding:"required"`
+}
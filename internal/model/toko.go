@@ -11,37 +11,132 @@
 // - File ini berisi struct Toko dan request/response DTOs
 // - Setiap user hanya dapat memiliki satu toko
 // - Toko dapat memiliki foto/logo
+// - Slug toko unik dan dipakai untuk URL storefront
+// - Toko dapat diikuti user, is_followed diisi untuk user yang login
+// - Profil toko berisi deskripsi, banner, alamat (provinsi/kota wilayah),
+//   kontak, dan jam buka per hari
+// - Toko dalam mode libur (vacation) tidak menerima transaksi, is_on_vacation
+//   dihitung dari vacation_until saat respons dibuat
 //
 // ============================================================================
 
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"evermos-api/internal/utils"
+	"time"
+)
 
 // Toko represents toko table
 type Toko struct {
-	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
-	IDUser    int        `gorm:"column:id_user;index" json:"user_id"`
-	NamaToko  string     `gorm:"column:nama_toko;type:varchar(255)" json:"nama_toko"`
-	URLFoto   string     `gorm:"column:url_toko;type:varchar(255)" json:"url_foto"`
-	UpdatedAt *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
-	CreatedAt *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
-	User      *User      `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+	ID              int               `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDUser          int               `gorm:"column:id_user;index" json:"user_id"`
+	NamaToko        string            `gorm:"column:nama_toko;type:varchar(255)" json:"nama_toko"`
+	Slug            string            `gorm:"type:varchar(255)" json:"slug"`
+	URLFoto         string            `gorm:"column:url_toko;type:varchar(255)" json:"url_foto"`
+	URLFotoSizes    map[string]string `gorm:"column:url_toko_sizes;type:text;serializer:json" json:"url_foto_sizes,omitempty"`
+	Deskripsi       string            `gorm:"column:deskripsi;type:text" json:"deskripsi,omitempty"`
+	URLBanner       string            `gorm:"column:url_banner;type:varchar(255)" json:"url_banner,omitempty"`
+	URLBannerSizes  map[string]string `gorm:"column:url_banner_sizes;type:text;serializer:json" json:"url_banner_sizes,omitempty"`
+	Alamat          string            `gorm:"column:alamat;type:text" json:"alamat,omitempty"`
+	IDProvinsi      *int              `gorm:"column:id_provinsi" json:"id_provinsi,omitempty"`
+	NamaProvinsi    string            `gorm:"column:nama_provinsi;type:varchar(255)" json:"nama_provinsi,omitempty"`
+	IDKota          *int              `gorm:"column:id_kota" json:"id_kota,omitempty"`
+	NamaKota        string            `gorm:"column:nama_kota;type:varchar(255)" json:"nama_kota,omitempty"`
+	NoTelp          string            `gorm:"column:no_telp;type:varchar(20)" json:"no_telp,omitempty"`
+	Email           string            `gorm:"column:email;type:varchar(255)" json:"email,omitempty"`
+	OpeningHours    []OpeningHour     `gorm:"column:opening_hours;type:text;serializer:json" json:"opening_hours,omitempty"`
+	IsVacation      bool              `gorm:"column:is_vacation;type:tinyint(1);default:0" json:"is_on_vacation"`
+	VacationMessage string            `gorm:"column:vacation_message;type:varchar(255)" json:"vacation_message,omitempty"`
+	VacationUntil   *time.Time        `gorm:"column:vacation_until;type:datetime" json:"vacation_until,omitempty"`
+	RatingAvg       float64           `gorm:"column:rating_avg;type:decimal(3,2);default:0;<-:create" json:"rating_avg"`
+	RatingCount     int               `gorm:"column:rating_count;default:0;<-:create" json:"rating_count"`
+	RatingTotal     int               `gorm:"column:rating_total;default:0;<-:create" json:"-"`
+	TrustLevel      int               `gorm:"column:trust_level;default:0" json:"-"`
+	FollowerCount   int               `gorm:"column:follower_count;default:0;<-:create" json:"follower_count"`
+	UpdatedAt       *time.Time        `gorm:"column:updated_at;type:date" json:"updated_at"`
+	CreatedAt       *time.Time        `gorm:"column:created_at;type:date" json:"created_at"`
+	User            *User             `gorm:"foreignKey:IDUser;references:ID" json:"-"`
 }
 
 func (Toko) TableName() string {
 	return "toko"
 }
 
+// MarshalJSON exposes toko photo URLs as absolute media URLs and the current
+// vacation state
+func (t Toko) MarshalJSON() ([]byte, error) {
+	type toko Toko
+	out := toko(t)
+	out.URLFoto = utils.MediaURL(t.URLFoto)
+	out.URLFotoSizes = utils.MediaURLs(t.URLFotoSizes)
+	out.URLBanner = utils.MediaURL(t.URLBanner)
+	out.URLBannerSizes = utils.MediaURLs(t.URLBannerSizes)
+	out.IsVacation = t.OnVacation(time.Now())
+	if !out.IsVacation {
+		out.VacationMessage = ""
+		out.VacationUntil = nil
+	}
+	return json.Marshal(out)
+}
+
+// OnVacation reports whether the toko is closed for orders at now, vacation
+// ends by itself at vacation_until
+func (t Toko) OnVacation(now time.Time) bool {
+	return t.IsVacation && (t.VacationUntil == nil || now.Before(*t.VacationUntil))
+}
+
+// OpeningHour is the opening time of a toko on one day of the week
+type OpeningHour struct {
+	Day   string `json:"day"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Days of the week accepted in opening hours
+var OpeningDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
 // TokoResponse DTO
 type TokoResponse struct {
-	ID       int    `json:"id"`
-	NamaToko string `json:"nama_toko"`
-	URLFoto  string `json:"url_foto"`
-	UserID   int    `json:"user_id,omitempty"`
+	ID              int               `json:"id"`
+	NamaToko        string            `json:"nama_toko"`
+	Slug            string            `json:"slug"`
+	URLFoto         string            `json:"url_foto"`
+	URLFotoSizes    map[string]string `json:"url_foto_sizes,omitempty"`
+	RatingAvg       float64           `json:"rating_avg"`
+	RatingCount     int               `json:"rating_count"`
+	FollowerCount   int               `json:"follower_count"`
+	IsFollowed      *bool             `json:"is_followed,omitempty"`
+	Deskripsi       string            `json:"deskripsi"`
+	URLBanner       string            `json:"url_banner"`
+	URLBannerSizes  map[string]string `json:"url_banner_sizes,omitempty"`
+	Alamat          string            `json:"alamat"`
+	Provinsi        *Province         `json:"provinsi,omitempty"`
+	Kota            *City             `json:"kota,omitempty"`
+	NoTelp          string            `json:"no_telp"`
+	Email           string            `json:"email"`
+	OpeningHours    []OpeningHour     `json:"opening_hours"`
+	IsOnVacation    bool              `json:"is_on_vacation"`
+	VacationMessage string            `json:"vacation_message,omitempty"`
+	VacationUntil   *time.Time        `json:"vacation_until,omitempty"`
+	UserID          int               `json:"user_id,omitempty"`
 }
 
-// UpdateTokoRequest DTO
+// UpdateTokoRequest DTO, omitted profile fields are kept and an empty value
+// clears them. opening_hours is a JSON array of {day, open, close} with
+// HH:MM times and vacation_until is a YYYY-MM-DD date the toko reopens on.
 type UpdateTokoRequest struct {
-	NamaToko string `form:"nama_toko"`
+	NamaToko        string  `form:"nama_toko"`
+	Slug            string  `form:"slug"`
+	Deskripsi       *string `form:"deskripsi"`
+	Alamat          *string `form:"alamat"`
+	IDProvinsi      *string `form:"id_provinsi"`
+	IDKota          *string `form:"id_kota"`
+	NoTelp          *string `form:"no_telp" binding:"omitempty,max=20"`
+	Email           *string `form:"email"`
+	OpeningHours    *string `form:"opening_hours"`
+	IsOnVacation    *bool   `form:"is_on_vacation"`
+	VacationMessage *string `form:"vacation_message" binding:"omitempty,max=255"`
+	VacationUntil   *string `form:"vacation_until"`
 }
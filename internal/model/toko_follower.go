@@ -0,0 +1,33 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : toko_follower.go
+// Description  : Model untuk entitas TokoFollower (user mengikuti toko)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct TokoFollower
+// - Setiap user hanya dapat mengikuti satu toko sekali (unique index)
+// - Jumlah follower disimpan di toko.follower_count agar tidak dihitung ulang
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// TokoFollower represents toko_follower table
+type TokoFollower struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDUser    int        `gorm:"column:id_user;uniqueIndex:idx_toko_follower_user_toko,priority:1" json:"-"`
+	IDToko    int        `gorm:"column:id_toko;uniqueIndex:idx_toko_follower_user_toko,priority:2;index" json:"toko_id"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+	User      *User      `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+	Toko      *Toko      `gorm:"foreignKey:IDToko;references:ID" json:"toko,omitempty"`
+}
+
+func (TokoFollower) TableName() string {
+	return "toko_follower"
+}
@@ -11,6 +11,10 @@
 // - File ini berisi struct Trx dan DetailTrx
 // - Trx menyimpan informasi transaksi utama
 // - DetailTrx menyimpan detail produk dalam transaksi
+// - DetailTrx produk pre-order memiliki estimasi tanggal kirim (ship_by)
+// - Alamat pengiriman hanya wajib jika transaksi berisi produk fisik
+// - Trx dibuat dengan status_bayar unpaid dan menjadi paid saat pembayaran
+//   dikonfirmasi
 //
 // ============================================================================
 
@@ -18,14 +22,22 @@ package model
 
 import "time"
 
+// Trx payment statuses
+const (
+	TrxStatusUnpaid = "unpaid"
+	TrxStatusPaid   = "paid"
+)
+
 // Trx represents trx table
 type Trx struct {
 	ID               int         `gorm:"primaryKey;autoIncrement" json:"id"`
 	IDUser           int         `gorm:"column:id_user;index" json:"id_user"`
-	AlamatPengiriman int         `gorm:"column:alamat_pengiriman;index" json:"alamat_pengiriman"`
+	AlamatPengiriman *int        `gorm:"column:alamat_pengiriman;index" json:"alamat_pengiriman"`
 	HargaTotal       int         `gorm:"column:harga_total" json:"harga_total"`
 	KodeInvoice      string      `gorm:"column:kode_invoice;type:varchar(255)" json:"kode_invoice"`
 	MethodBayar      string      `gorm:"column:method_bayar;type:varchar(255)" json:"method_bayar"`
+	StatusBayar      string      `gorm:"column:status_bayar;type:varchar(20);default:'unpaid';index" json:"status_bayar"`
+	PaidAt           *time.Time  `gorm:"column:paid_at;type:datetime" json:"paid_at,omitempty"`
 	UpdatedAt        *time.Time  `gorm:"column:updated_at;type:date" json:"updated_at"`
 	CreatedAt        *time.Time  `gorm:"column:created_at;type:date" json:"created_at"`
 	User             *User       `gorm:"foreignKey:IDUser;references:ID" json:"-"`
@@ -45,6 +57,8 @@ type DetailTrx struct {
 	IDToko      int        `gorm:"column:id_toko;index" json:"id_toko"`
 	Kuantitas   int        `gorm:"type:int" json:"kuantitas"`
 	HargaTotal  int        `gorm:"column:harga_total" json:"harga_total"`
+	ShipBy      *time.Time `gorm:"column:ship_by;type:date;index" json:"ship_by,omitempty"`
+	ShippedAt   *time.Time `gorm:"column:shipped_at;type:datetime" json:"shipped_at,omitempty"`
 	UpdatedAt   *time.Time `gorm:"column:updated_at;type:date" json:"updated_at"`
 	CreatedAt   *time.Time `gorm:"column:created_at;type:date" json:"created_at"`
 	Trx         *Trx       `gorm:"foreignKey:IDTrx;references:ID" json:"-"`
@@ -58,7 +72,7 @@ func (DetailTrx) TableName() string {
 
 // CreateTrxRequest DTO
 type CreateTrxRequest struct {
-	AlamatPengiriman int                `json:"alamat_kirim" binding:"required"`
+	AlamatPengiriman int                `json:"alamat_kirim"`
 	MethodBayar      string             `json:"method_bayar" binding:"required"`
 	DetailTrx        []DetailTrxRequest `json:"detail_trx" binding:"required,min=1"`
 }
@@ -0,0 +1,33 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : wishlist.go
+// Description  : Model untuk wishlist (produk favorit) user
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi struct Wishlist
+// - Satu produk hanya dapat masuk wishlist user satu kali
+// - User menerima notifikasi saat harga produk di wishlist turun
+//
+// ============================================================================
+
+package model
+
+import "time"
+
+// Wishlist represents wishlist table
+type Wishlist struct {
+	ID        int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	IDUser    int        `gorm:"column:id_user;uniqueIndex:idx_wishlist_user_produk,priority:1" json:"-"`
+	IDProduk  int        `gorm:"column:id_produk;uniqueIndex:idx_wishlist_user_produk,priority:2;index" json:"product_id"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime" json:"created_at"`
+	User      *User      `gorm:"foreignKey:IDUser;references:ID" json:"-"`
+	Produk    *Produk    `gorm:"foreignKey:IDProduk;references:ID" json:"product,omitempty"`
+}
+
+func (Wishlist) TableName() string {
+	return "wishlist"
+}
@@ -0,0 +1,59 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : banned_keyword_repository.go
+// Description  : Repository layer untuk operasi database BannedKeyword
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk CRUD BannedKeyword
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// BannedKeywordRepository interface
+type BannedKeywordRepository interface {
+	Create(keyword *model.BannedKeyword) error
+	FindAll() ([]model.BannedKeyword, error)
+	Delete(id int) error
+}
+
+type bannedKeywordRepository struct {
+	db *gorm.DB
+}
+
+// NewBannedKeywordRepository creates new banned keyword repository
+func NewBannedKeywordRepository(db *gorm.DB) BannedKeywordRepository {
+	return &bannedKeywordRepository{db: db}
+}
+
+func (r *bannedKeywordRepository) Create(keyword *model.BannedKeyword) error {
+	return r.db.Create(keyword).Error
+}
+
+func (r *bannedKeywordRepository) FindAll() ([]model.BannedKeyword, error) {
+	var keywords []model.BannedKeyword
+	err := r.db.Order("keyword ASC").Find(&keywords).Error
+	return keywords, err
+}
+
+func (r *bannedKeywordRepository) Delete(id int) error {
+	result := r.db.Delete(&model.BannedKeyword{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : category_attribute_repository.go
+// Description  : Repository layer untuk operasi database CategoryAttribute
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk CRUD skema atribut
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// CategoryAttributeRepository interface
+type CategoryAttributeRepository interface {
+	Create(attribute *model.CategoryAttribute) error
+	FindByID(id int) (*model.CategoryAttribute, error)
+	FindByCategoryIDs(categoryIDs []int) ([]model.CategoryAttribute, error)
+	Update(attribute *model.CategoryAttribute) error
+	Delete(id int) error
+}
+
+type categoryAttributeRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryAttributeRepository creates new category attribute repository
+func NewCategoryAttributeRepository(db *gorm.DB) CategoryAttributeRepository {
+	return &categoryAttributeRepository{db: db}
+}
+
+func (r *categoryAttributeRepository) Create(attribute *model.CategoryAttribute) error {
+	return r.db.Create(attribute).Error
+}
+
+func (r *categoryAttributeRepository) FindByID(id int) (*model.CategoryAttribute, error) {
+	var attribute model.CategoryAttribute
+	err := r.db.First(&attribute, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+func (r *categoryAttributeRepository) FindByCategoryIDs(categoryIDs []int) ([]model.CategoryAttribute, error) {
+	var attributes []model.CategoryAttribute
+	if len(categoryIDs) == 0 {
+		return attributes, nil
+	}
+	err := r.db.Where("id_category IN ?", categoryIDs).Order("id ASC").Find(&attributes).Error
+	return attributes, err
+}
+
+func (r *categoryAttributeRepository) Update(attribute *model.CategoryAttribute) error {
+	return r.db.Save(attribute).Error
+}
+
+func (r *categoryAttributeRepository) Delete(id int) error {
+	return r.db.Delete(&model.CategoryAttribute{}, id).Error
+}
@@ -29,6 +29,8 @@ type CategoryRepository interface {
 	FindAll() ([]model.Category, error)
 	Update(category *model.Category) error
 	Delete(id int) error
+	CountChildren(id int) (int64, error)
+	CountProduk(id int) (int64, error)
 }
 
 type categoryRepository struct {
@@ -63,6 +65,30 @@ func (r *categoryRepository) Update(category *model.Category) error {
 	return r.db.Save(category).Error
 }
 
+// Delete removes a category together with its attribute schema
 func (r *categoryRepository) Delete(id int) error {
-	return r.db.Delete(&model.Category{}, id).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id_category = ?", id).Delete(&model.CategoryAttribute{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Category{}, id).Error
+	})
+}
+
+func (r *categoryRepository) CountChildren(id int) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Category{}).Where("id_parent = ?", id).Count(&count).Error
+	return count, err
+}
+
+// CountProduk counts products in the category as their main or a secondary
+// category, soft-deleted ones included
+func (r *categoryRepository) CountProduk(id int) (int64, error) {
+	secondary := r.db.Model(&model.ProdukCategory{}).Select("id_produk").Where("id_category = ?", id)
+
+	var count int64
+	err := r.db.Model(&model.Produk{}).
+		Where("id_category = ? OR id IN (?)", id, secondary).
+		Count(&count).Error
+	return count, err
 }
@@ -0,0 +1,86 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : cursor.go
+// Description  : Helper keyset pagination untuk repository
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk menerapkan cursor pada query GORM
+// - Query mengambil limit+1 baris untuk mendeteksi halaman berikutnya
+// - Urutan berdasarkan kolom id agar stabil pada data yang terus bertambah
+// - Urutan berdasarkan skor atau waktu (menurun) memakai Key cursor dengan id
+//   sebagai pemecah nilai yang sama
+//
+// ============================================================================
+
+package repository
+
+import (
+	"errors"
+	"evermos-api/internal/utils"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// applyCursor applies keyset condition, ordering and lookahead limit. Only
+// the id is used, a cursor with a Key belongs to a score or time ordering
+// and is refused.
+func applyCursor(query *gorm.DB, column string, cursor *utils.Cursor, limit int) (*gorm.DB, error) {
+	if cursor == nil {
+		return query.Order(column + " ASC").Limit(limit + 1), nil
+	}
+	if cursor.Key != "" {
+		return nil, errors.New("invalid cursor")
+	}
+
+	if cursor.Backward {
+		return query.Where(column+" < ?", cursor.ID).Order(column + " DESC").Limit(limit + 1), nil
+	}
+
+	return query.Where(column+" > ?", cursor.ID).Order(column + " ASC").Limit(limit + 1), nil
+}
+
+// applyScoreCursor pages by an integer column in descending order, ties are
+// broken by id. The cursor Key holds the column value of the boundary row.
+func applyScoreCursor(query *gorm.DB, column string, cursor *utils.Cursor, limit int) (*gorm.DB, error) {
+	if cursor == nil {
+		return query.Order(column + " DESC, id DESC").Limit(limit + 1), nil
+	}
+
+	score, err := strconv.Atoi(cursor.Key)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return applyDescCursor(query, column, score, cursor, limit), nil
+}
+
+// applyTimeCursor pages by a datetime column, newest first, ties are broken
+// by id. The cursor Key holds the unix time of the boundary row.
+func applyTimeCursor(query *gorm.DB, column string, cursor *utils.Cursor, limit int) (*gorm.DB, error) {
+	if cursor == nil {
+		return query.Order(column + " DESC, id DESC").Limit(limit + 1), nil
+	}
+
+	unix, err := strconv.ParseInt(cursor.Key, 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return applyDescCursor(query, column, time.Unix(unix, 0), cursor, limit), nil
+}
+
+// applyDescCursor applies the keyset condition of a descending (column, id)
+// ordering around the boundary value
+func applyDescCursor(query *gorm.DB, column string, value interface{}, cursor *utils.Cursor, limit int) *gorm.DB {
+	if cursor.Backward {
+		return query.Where("("+column+" > ? OR ("+column+" = ? AND id > ?))", value, value, cursor.ID).
+			Order(column + " ASC, id ASC").Limit(limit + 1)
+	}
+
+	return query.Where("("+column+" < ? OR ("+column+" = ? AND id < ?))", value, value, cursor.ID).
+		Order(column + " DESC, id DESC").Limit(limit + 1)
+}
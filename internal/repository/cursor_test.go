@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"evermos-api/internal/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cursorRow struct {
+	ID int
+}
+
+func TestApplyCursor(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name     string
+		cursor   *utils.Cursor
+		expected string
+		vars     []interface{}
+	}{
+		{
+			name:     "First Page",
+			expected: "SELECT * FROM `cursor_rows` ORDER BY id ASC LIMIT ?",
+			vars:     []interface{}{11},
+		},
+		{
+			name:     "Forward",
+			cursor:   &utils.Cursor{ID: 5},
+			expected: "SELECT * FROM `cursor_rows` WHERE id > ? ORDER BY id ASC LIMIT ?",
+			vars:     []interface{}{5, 11},
+		},
+		{
+			name:     "Backward",
+			cursor:   &utils.Cursor{ID: 5, Backward: true},
+			expected: "SELECT * FROM `cursor_rows` WHERE id < ? ORDER BY id DESC LIMIT ?",
+			vars:     []interface{}{5, 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := applyCursor(db.Model(&cursorRow{}), "id", tt.cursor, 10)
+			require.NoError(t, err)
+
+			stmt := query.Find(&[]cursorRow{}).Statement
+			assert.Equal(t, tt.expected, stmt.SQL.String())
+			assert.Equal(t, tt.vars, stmt.Vars)
+		})
+	}
+
+	_, err := applyCursor(db.Model(&cursorRow{}), "id", &utils.Cursor{Key: "40", ID: 5}, 10)
+	assert.Error(t, err)
+}
+
+func TestApplyScoreCursor(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name     string
+		cursor   *utils.Cursor
+		expected string
+		vars     []interface{}
+	}{
+		{
+			name:     "First Page",
+			expected: "SELECT * FROM `cursor_rows` ORDER BY popularity_score DESC, id DESC LIMIT ?",
+			vars:     []interface{}{11},
+		},
+		{
+			name:     "Forward",
+			cursor:   &utils.Cursor{Key: "40", ID: 5},
+			expected: "SELECT * FROM `cursor_rows` WHERE (popularity_score < ? OR (popularity_score = ? AND id < ?)) ORDER BY popularity_score DESC, id DESC LIMIT ?",
+			vars:     []interface{}{40, 40, 5, 11},
+		},
+		{
+			name:     "Backward",
+			cursor:   &utils.Cursor{Key: "40", ID: 5, Backward: true},
+			expected: "SELECT * FROM `cursor_rows` WHERE (popularity_score > ? OR (popularity_score = ? AND id > ?)) ORDER BY popularity_score ASC, id ASC LIMIT ?",
+			vars:     []interface{}{40, 40, 5, 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := applyScoreCursor(db.Model(&cursorRow{}), "popularity_score", tt.cursor, 10)
+			require.NoError(t, err)
+
+			stmt := query.Find(&[]cursorRow{}).Statement
+			assert.Equal(t, tt.expected, stmt.SQL.String())
+			assert.Equal(t, tt.vars, stmt.Vars)
+		})
+	}
+
+	_, err := applyScoreCursor(db.Model(&cursorRow{}), "popularity_score", &utils.Cursor{ID: 5}, 10)
+	assert.Error(t, err)
+}
+
+func TestApplyTimeCursor(t *testing.T) {
+	db := dryRunDB(t)
+	at := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	key := "1772352000"
+
+	tests := []struct {
+		name     string
+		cursor   *utils.Cursor
+		expected string
+		vars     []interface{}
+	}{
+		{
+			name:     "First Page",
+			expected: "SELECT * FROM `cursor_rows` ORDER BY publish_at DESC, id DESC LIMIT ?",
+			vars:     []interface{}{11},
+		},
+		{
+			name:     "Forward",
+			cursor:   &utils.Cursor{Key: key, ID: 5},
+			expected: "SELECT * FROM `cursor_rows` WHERE (publish_at < ? OR (publish_at = ? AND id < ?)) ORDER BY publish_at DESC, id DESC LIMIT ?",
+			vars:     []interface{}{at, at, 5, 11},
+		},
+		{
+			name:     "Backward",
+			cursor:   &utils.Cursor{Key: key, ID: 5, Backward: true},
+			expected: "SELECT * FROM `cursor_rows` WHERE (publish_at > ? OR (publish_at = ? AND id > ?)) ORDER BY publish_at ASC, id ASC LIMIT ?",
+			vars:     []interface{}{at, at, 5, 11},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := applyTimeCursor(db.Model(&cursorRow{}), "publish_at", tt.cursor, 10)
+			require.NoError(t, err)
+
+			stmt := query.Find(&[]cursorRow{}).Statement
+			assert.Equal(t, tt.expected, stmt.SQL.String())
+			require.Len(t, stmt.Vars, len(tt.vars))
+			for i, v := range tt.vars {
+				if expected, ok := v.(time.Time); ok {
+					assert.True(t, expected.Equal(stmt.Vars[i].(time.Time)))
+					continue
+				}
+				assert.Equal(t, v, stmt.Vars[i])
+			}
+		})
+	}
+
+	_, err := applyTimeCursor(db.Model(&cursorRow{}), "publish_at", &utils.Cursor{Key: "yesterday", ID: 5}, 10)
+	assert.Error(t, err)
+}
@@ -10,6 +10,7 @@
 // Notes:
 // - File ini berisi interface dan implementasi untuk CRUD DetailTrx
 // - DetailTrx menyimpan item-item dalam transaksi
+// - Pre-order toko yang belum dikirim dapat difilter yang sudah lewat ship_by
 // - Menggunakan GORM sebagai ORM
 //
 // ============================================================================
@@ -18,6 +19,7 @@ package repository
 
 import (
 	"evermos-api/internal/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -26,6 +28,9 @@ import (
 type DetailTrxRepository interface {
 	Create(detail *model.DetailTrx) error
 	FindByTrxID(trxID int) ([]model.DetailTrx, error)
+	FindByIDWithRelations(id int) (*model.DetailTrx, error)
+	FindPreordersByTokoID(tokoID int, overdueBefore *time.Time, limit, offset int) ([]model.DetailTrx, error)
+	Update(detail *model.DetailTrx) error
 }
 
 type detailTrxRepository struct {
@@ -46,3 +51,31 @@ func (r *detailTrxRepository) FindByTrxID(trxID int) ([]model.DetailTrx, error)
 	err := r.db.Where("id_trx = ?", trxID).Find(&details).Error
 	return details, err
 }
+
+func (r *detailTrxRepository) FindByIDWithRelations(id int) (*model.DetailTrx, error) {
+	var detail model.DetailTrx
+	err := r.db.Preload("Trx").Preload("LogProduk").First(&detail, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// FindPreordersByTokoID returns unshipped pre-order items of a toko, earliest
+// ship date first. A non nil overdueBefore keeps only items due before it.
+func (r *detailTrxRepository) FindPreordersByTokoID(tokoID int, overdueBefore *time.Time, limit, offset int) ([]model.DetailTrx, error) {
+	var details []model.DetailTrx
+	query := r.db.Where("id_toko = ? AND ship_by IS NOT NULL AND shipped_at IS NULL", tokoID)
+	if overdueBefore != nil {
+		query = query.Where("ship_by < ?", *overdueBefore)
+	}
+	err := query.Preload("Trx").Preload("LogProduk").
+		Order("ship_by ASC, id ASC").
+		Limit(limit).Offset(offset).
+		Find(&details).Error
+	return details, err
+}
+
+func (r *detailTrxRepository) Update(detail *model.DetailTrx) error {
+	return r.db.Save(detail).Error
+}
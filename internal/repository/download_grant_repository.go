@@ -0,0 +1,65 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : download_grant_repository.go
+// Description  : Repository layer untuk operasi database DownloadGrant
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk hak unduh produk digital
+// - Klaim unduhan memakai update bersyarat agar batas unduhan dan masa
+//   berlaku tidak bisa dilewati oleh request bersamaan
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DownloadGrantRepository interface
+type DownloadGrantRepository interface {
+	FindByTrxID(trxID int) ([]model.DownloadGrant, error)
+	FindByID(id int) (*model.DownloadGrant, error)
+	Claim(id int, now time.Time) (bool, error)
+}
+
+type downloadGrantRepository struct {
+	db *gorm.DB
+}
+
+// NewDownloadGrantRepository creates new download grant repository
+func NewDownloadGrantRepository(db *gorm.DB) DownloadGrantRepository {
+	return &downloadGrantRepository{db: db}
+}
+
+func (r *downloadGrantRepository) FindByTrxID(trxID int) ([]model.DownloadGrant, error) {
+	var grants []model.DownloadGrant
+	err := r.db.Where("id_trx = ?", trxID).Order("id ASC").Find(&grants).Error
+	return grants, err
+}
+
+func (r *downloadGrantRepository) FindByID(id int) (*model.DownloadGrant, error) {
+	var grant model.DownloadGrant
+	err := r.db.First(&grant, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// Claim uses one download of a grant, it reports false when the grant is
+// inactive, exhausted or expired. The table is named rather than modelled
+// since downloads is a create-only field GORM leaves out of model updates.
+func (r *downloadGrantRepository) Claim(id int, now time.Time) (bool, error) {
+	result := r.db.Table(model.DownloadGrant{}.TableName()).
+		Where("id = ? AND activated_at IS NOT NULL AND downloads < max_downloads AND expires_at > ?", id, now).
+		UpdateColumn("downloads", gorm.Expr("downloads + 1"))
+	return result.RowsAffected > 0, result.Error
+}
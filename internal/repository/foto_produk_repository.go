@@ -25,6 +25,7 @@ import (
 // FotoProdukRepository interface
 type FotoProdukRepository interface {
 	Create(foto *model.FotoProduk) error
+	FindByID(id int) (*model.FotoProduk, error)
 	FindByProdukID(produkID int) ([]model.FotoProduk, error)
 	DeleteByProdukID(produkID int) error
 }
@@ -42,9 +43,18 @@ func (r *fotoProdukRepository) Create(foto *model.FotoProduk) error {
 	return r.db.Create(foto).Error
 }
 
+func (r *fotoProdukRepository) FindByID(id int) (*model.FotoProduk, error) {
+	var foto model.FotoProduk
+	err := r.db.First(&foto, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &foto, nil
+}
+
 func (r *fotoProdukRepository) FindByProdukID(produkID int) ([]model.FotoProduk, error) {
 	var fotos []model.FotoProduk
-	err := r.db.Where("id_produk = ?", produkID).Find(&fotos).Error
+	err := r.db.Where("id_produk = ?", produkID).Order("posisi ASC, id ASC").Find(&fotos).Error
 	return fotos, err
 }
 
@@ -27,6 +27,7 @@ type LogProdukRepository interface {
 	Create(log *model.LogProduk) error
 	FindByID(id int) (*model.LogProduk, error)
 	ExistsByProdukID(produkID int) (bool, error)
+	FindSalesByProdukID(produkID int) ([]model.ProdukSale, error)
 }
 
 type logProdukRepository struct {
@@ -59,3 +60,18 @@ func (r *logProdukRepository) ExistsByProdukID(produkID int) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// FindSalesByProdukID lists sold order lines of paid transactions of a
+// product with the list and promo price snapshot taken at sale time, oldest
+// first. Unpaid orders may still be abandoned so they are not sales yet.
+func (r *logProdukRepository) FindSalesByProdukID(produkID int) ([]model.ProdukSale, error) {
+	var sales []model.ProdukSale
+	err := r.db.Table("detail_trx").
+		Select("log_produk.harga_konsumen, log_produk.harga_promo, detail_trx.kuantitas, detail_trx.harga_total, log_produk.created_at AS sold_at").
+		Joins("JOIN log_produk ON log_produk.id = detail_trx.id_log_produk").
+		Joins("JOIN trx ON trx.id = detail_trx.id_trx").
+		Where("log_produk.id_produk = ? AND trx.status_bayar = ?", produkID, model.TrxStatusPaid).
+		Order("detail_trx.id ASC").
+		Scan(&sales).Error
+	return sales, err
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestLogProdukRepository_FindSalesByProdukID(t *testing.T) {
+	db := dryRunDB(t)
+
+	var stmt *gorm.Statement
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:capture", func(tx *gorm.DB) {
+		stmt = tx.Statement
+	}))
+
+	// Dry run builds the query but cannot scan rows
+	_, err := NewLogProdukRepository(db).FindSalesByProdukID(7)
+	assert.ErrorIs(t, err, gorm.ErrDryRunModeUnsupported)
+	require.NotNil(t, stmt)
+
+	// Only lines of paid transactions are read as sales
+	assert.Equal(t, "SELECT log_produk.harga_konsumen, log_produk.harga_promo, detail_trx.kuantitas, detail_trx.harga_total, log_produk.created_at AS sold_at "+
+		"FROM `detail_trx` JOIN log_produk ON log_produk.id = detail_trx.id_log_produk JOIN trx ON trx.id = detail_trx.id_trx "+
+		"WHERE log_produk.id_produk = ? AND trx.status_bayar = ? ORDER BY detail_trx.id ASC", stmt.SQL.String())
+	assert.Equal(t, []interface{}{7, model.TrxStatusPaid}, stmt.Vars)
+}
@@ -0,0 +1,66 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : notification_repository.go
+// Description  : Repository layer untuk operasi database Notification
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk Notification
+// - Notifikasi diurutkan dari yang terbaru
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository interface
+type NotificationRepository interface {
+	Create(notification *model.Notification) error
+	FindByID(id int) (*model.Notification, error)
+	FindByUserID(userID int, limit, offset int) ([]model.Notification, error)
+	Update(notification *model.Notification) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates new notification repository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(notification *model.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+func (r *notificationRepository) FindByID(id int) (*model.Notification, error) {
+	var notification model.Notification
+	err := r.db.First(&notification, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *notificationRepository) FindByUserID(userID int, limit, offset int) ([]model.Notification, error) {
+	var notifications []model.Notification
+	err := r.db.Where("id_user = ?", userID).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *notificationRepository) Update(notification *model.Notification) error {
+	return r.db.Save(notification).Error
+}
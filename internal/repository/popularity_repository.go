@@ -0,0 +1,88 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : popularity_repository.go
+// Description  : Repository layer untuk view dan popularitas produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi penyimpanan agregat view harian dan perhitungan skor
+// - View ditambahkan dengan upsert sehingga flush berulang aman
+// - Skor = jumlah view + bobot penjualan x kuantitas terjual dalam periode
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PopularityRepository interface
+type PopularityRepository interface {
+	AddViews(views []model.ProdukViewDaily) error
+	RefreshScores(since time.Time, saleWeight int) (int64, error)
+	FindTrending(since time.Time, saleWeight int, limit int) ([]model.Produk, error)
+}
+
+type popularityRepository struct {
+	db *gorm.DB
+}
+
+// NewPopularityRepository creates new popularity repository
+func NewPopularityRepository(db *gorm.DB) PopularityRepository {
+	return &popularityRepository{db: db}
+}
+
+// AddViews adds view counts to the daily aggregates
+func (r *popularityRepository) AddViews(views []model.ProdukViewDaily) error {
+	if len(views) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{"views": gorm.Expr("views + VALUES(views)")}),
+	}).Create(&views).Error
+}
+
+// RefreshScores recomputes the popularity score of every product from views
+// and sales since the given time
+func (r *popularityRepository) RefreshScores(since time.Time, saleWeight int) (int64, error) {
+	result := r.db.Exec(
+		"UPDATE produk "+
+			"LEFT JOIN ("+produkActivitySQL+") activity ON activity.id_produk = produk.id "+
+			"SET produk.popularity_score = COALESCE(activity.score, 0)",
+		since, saleWeight, since,
+	)
+	return result.RowsAffected, result.Error
+}
+
+// FindTrending returns visible products with the highest score since the
+// given time
+func (r *popularityRepository) FindTrending(since time.Time, saleWeight int, limit int) ([]model.Produk, error) {
+	var produks []model.Produk
+	err := r.db.Select("produk.*").
+		Joins("JOIN ("+produkActivitySQL+") activity ON activity.id_produk = produk.id", since, saleWeight, since).
+		Where("produk.deleted_at IS NULL").
+		Scopes(visibleProduk(0)).
+		Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("BundleItems.Component").
+		Order("activity.score DESC, produk.id DESC").
+		Limit(limit).
+		Find(&produks).Error
+	return produks, err
+}
+
+// produkActivitySQL scores products by views plus weighted units sold, its
+// arguments are the views start date, the sale weight and the sales start date
+const produkActivitySQL = "SELECT id_produk, SUM(score) AS score FROM (" +
+	"SELECT id_produk, views AS score FROM produk_view_daily WHERE date >= ? " +
+	"UNION ALL " +
+	"SELECT log_produk.id_produk, detail_trx.kuantitas * ? FROM detail_trx " +
+	"JOIN log_produk ON log_produk.id = detail_trx.id_log_produk " +
+	"WHERE detail_trx.created_at >= ?" +
+	") scores GROUP BY id_produk"
@@ -0,0 +1,43 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : price_history_repository.go
+// Description  : Repository layer untuk operasi database PriceHistory
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk riwayat harga produk
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// PriceHistoryRepository interface
+type PriceHistoryRepository interface {
+	FindByProdukID(produkID int) ([]model.PriceHistory, error)
+}
+
+type priceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPriceHistoryRepository creates new price history repository
+func NewPriceHistoryRepository(db *gorm.DB) PriceHistoryRepository {
+	return &priceHistoryRepository{db: db}
+}
+
+// FindByProdukID lists price changes of a product, oldest first
+func (r *priceHistoryRepository) FindByProdukID(produkID int) ([]model.PriceHistory, error) {
+	var history []model.PriceHistory
+	err := r.db.Where("id_produk = ?", produkID).Order("changed_at ASC, id ASC").Find(&history).Error
+	return history, err
+}
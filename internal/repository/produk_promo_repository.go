@@ -0,0 +1,91 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : produk_promo_repository.go
+// Description  : Repository layer untuk operasi database ProdukPromo
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk jadwal harga promo
+// - Promo aktif = sedang dalam jadwal dan quota belum habis
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProdukPromoRepository interface
+type ProdukPromoRepository interface {
+	Create(promo *model.ProdukPromo) error
+	FindByID(id int) (*model.ProdukPromo, error)
+	FindByProdukID(produkID int) ([]model.ProdukPromo, error)
+	FindActiveByProdukIDs(produkIDs []int, at time.Time) ([]model.ProdukPromo, error)
+	CountOverlapping(produkID int, startAt, endAt time.Time) (int64, error)
+	Delete(id int) error
+}
+
+type produkPromoRepository struct {
+	db *gorm.DB
+}
+
+// NewProdukPromoRepository creates new produk promo repository
+func NewProdukPromoRepository(db *gorm.DB) ProdukPromoRepository {
+	return &produkPromoRepository{db: db}
+}
+
+func (r *produkPromoRepository) Create(promo *model.ProdukPromo) error {
+	return r.db.Create(promo).Error
+}
+
+func (r *produkPromoRepository) FindByID(id int) (*model.ProdukPromo, error) {
+	var promo model.ProdukPromo
+	err := r.db.First(&promo, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promo, nil
+}
+
+func (r *produkPromoRepository) FindByProdukID(produkID int) ([]model.ProdukPromo, error) {
+	var promos []model.ProdukPromo
+	err := r.db.Where("id_produk = ?", produkID).Order("start_at DESC").Find(&promos).Error
+	return promos, err
+}
+
+func (r *produkPromoRepository) FindActiveByProdukIDs(produkIDs []int, at time.Time) ([]model.ProdukPromo, error) {
+	var promos []model.ProdukPromo
+	if len(produkIDs) == 0 {
+		return promos, nil
+	}
+	err := r.db.Scopes(activePromo(at)).Where("id_produk IN ?", produkIDs).Find(&promos).Error
+	return promos, err
+}
+
+// CountOverlapping counts promos of a product whose schedule intersects the given window
+func (r *produkPromoRepository) CountOverlapping(produkID int, startAt, endAt time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.ProdukPromo{}).
+		Where("id_produk = ? AND start_at < ? AND end_at > ?", produkID, endAt, startAt).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *produkPromoRepository) Delete(id int) error {
+	return r.db.Delete(&model.ProdukPromo{}, id).Error
+}
+
+// activePromo limits promos to those running at the given time with quota left
+func activePromo(at time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("start_at <= ? AND end_at > ? AND (quota = 0 OR sold < quota)", at, at)
+	}
+}
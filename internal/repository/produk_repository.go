@@ -18,6 +18,8 @@ package repository
 
 import (
 	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -26,8 +28,13 @@ import (
 type ProdukRepository interface {
 	Create(produk *model.Produk) error
 	FindByID(id int) (*model.Produk, error)
-	FindByIDWithRelations(id int) (*model.Produk, error)
+	FindByIDWithRelations(id int, viewerTokoID int) (*model.Produk, error)
 	FindAll(limit, offset int, filters map[string]interface{}) ([]model.Produk, error)
+	FindAllByCursor(limit int, cursor *utils.Cursor, filters map[string]interface{}) ([]model.Produk, error)
+	FindAllBySlug(slug string, tokoID int) ([]model.Produk, error)
+	FindDeletedByID(id int) (*model.Produk, error)
+	FindDeletedByTokoID(tokoID int, limit, offset int) ([]model.Produk, error)
+	FindDeletedBefore(cutoff time.Time, limit int) ([]model.Produk, error)
 	Update(produk *model.Produk) error
 	Delete(id int) error
 }
@@ -55,10 +62,12 @@ func (r *produkRepository) FindByID(id int) (*model.Produk, error) {
 	return &produk, nil
 }
 
-func (r *produkRepository) FindByIDWithRelations(id int) (*model.Produk, error) {
+// FindByIDWithRelations finds a published product, unpublished products are
+// only returned to their own toko (viewerTokoID)
+func (r *produkRepository) FindByIDWithRelations(id int, viewerTokoID int) (*model.Produk, error) {
 	var produk model.Produk
 	// err := r.db.Preload("Toko").Preload("Category").Preload("Photos").First(&produk, id).Error
-	err := r.db.Where("deleted_at IS NULL").Preload("Toko").Preload("Category").Preload("Photos").First(&produk, id).Error
+	err := r.db.Where("deleted_at IS NULL").Scopes(visibleProduk(viewerTokoID)).Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("Attributes").Preload("Tags").Preload("Categories").Preload("BundleItems.Component").First(&produk, id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -68,14 +77,138 @@ func (r *produkRepository) FindByIDWithRelations(id int) (*model.Produk, error)
 func (r *produkRepository) FindAll(limit, offset int, filters map[string]interface{}) ([]model.Produk, error) {
 	var produks []model.Produk
 	// query := r.db.Preload("Toko").Preload("Category").Preload("Photos").Limit(limit).Offset(offset)
-	query := r.db.Where("deleted_at IS NULL").Preload("Toko").Preload("Category").Preload("Photos").Limit(limit).Offset(offset)
-	// Apply filters
+	query := r.db.Where("deleted_at IS NULL").Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("Attributes").Preload("Tags").Preload("Categories").Preload("BundleItems.Component").Limit(limit).Offset(offset)
+	query = applyProdukFilters(query, filters)
+	if sort, _ := filters["sort"].(string); sort == model.ProdukSortPopular {
+		query = query.Order("popularity_score DESC, id DESC")
+	}
+
+	err := query.Find(&produks).Error
+	return produks, err
+}
+
+func (r *produkRepository) FindAllByCursor(limit int, cursor *utils.Cursor, filters map[string]interface{}) ([]model.Produk, error) {
+	var produks []model.Produk
+	query := r.db.Where("deleted_at IS NULL").Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("Attributes").Preload("Tags").Preload("Categories").Preload("BundleItems.Component")
+	query = applyProdukFilters(query, filters)
+	var err error
+	if sort, _ := filters["sort"].(string); sort == model.ProdukSortPopular {
+		query, err = applyScoreCursor(query, "popularity_score", cursor, limit)
+	} else {
+		query, err = applyCursor(query, "id", cursor, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&produks).Error
+	return produks, err
+}
+
+// FindAllBySlug finds products by slug, tokoID 0 searches across all toko
+func (r *produkRepository) FindAllBySlug(slug string, tokoID int) ([]model.Produk, error) {
+	var produks []model.Produk
+	query := r.db.Where("deleted_at IS NULL AND slug = ?", slug).Scopes(visibleProduk(0)).Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("Attributes").Preload("Tags").Preload("Categories").Preload("BundleItems.Component")
+	if tokoID > 0 {
+		query = query.Where("id_toko = ?", tokoID)
+	}
+
+	err := query.Find(&produks).Error
+	return produks, err
+}
+
+func (r *produkRepository) FindDeletedByID(id int) (*model.Produk, error) {
+	var produk model.Produk
+	err := r.db.Where("deleted_at IS NOT NULL").First(&produk, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &produk, nil
+}
+
+// FindDeletedByTokoID lists soft-deleted products of a toko, newest first
+func (r *produkRepository) FindDeletedByTokoID(tokoID int, limit, offset int) ([]model.Produk, error) {
+	var produks []model.Produk
+	err := r.db.Where("deleted_at IS NOT NULL AND id_toko = ?", tokoID).
+		Preload("Category").
+		Preload("Photos", orderedPhotos).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&produks).Error
+	return produks, err
+}
+
+// FindDeletedBefore lists products soft-deleted before cutoff with their photos
+func (r *produkRepository) FindDeletedBefore(cutoff time.Time, limit int) ([]model.Produk, error) {
+	var produks []model.Produk
+	err := r.db.Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+		Preload("Photos").
+		Order("id ASC").
+		Limit(limit).
+		Find(&produks).Error
+	return produks, err
+}
+
+// visibleProduk limits products to approved, published ones (including
+// scheduled products whose publish time has passed) plus those owned by
+// viewerTokoID
+func visibleProduk(viewerTokoID int) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		visible := "(moderation_status = ? AND (status = ? OR (status = ? AND publish_at <= ?)))"
+		args := []interface{}{model.ModerationApproved, model.ProdukStatusPublished, model.ProdukStatusScheduled, time.Now()}
+		if viewerTokoID > 0 {
+			return db.Where("("+visible+" OR id_toko = ?)", append(args, viewerTokoID)...)
+		}
+		return db.Where(visible, args...)
+	}
+}
+
+// orderedPhotos preloads photos by their display position
+func orderedPhotos(db *gorm.DB) *gorm.DB {
+	return db.Order("posisi ASC, id ASC")
+}
+
+// applyProdukFilters applies listing filters shared by offset and cursor queries
+func applyProdukFilters(query *gorm.DB, filters map[string]interface{}) *gorm.DB {
+	// Unpublished products are only listed to their owner or admin
+	if includeUnpublished, _ := filters["include_unpublished"].(bool); !includeUnpublished {
+		query = query.Scopes(visibleProduk(0))
+	}
+
+	if status, ok := filters["status"].(string); ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if moderation, ok := filters["moderation_status"].(string); ok && moderation != "" {
+		query = query.Where("moderation_status = ?", moderation)
+	}
+
 	if namaProduk, ok := filters["nama_produk"].(string); ok && namaProduk != "" {
 		query = query.Where("nama_produk LIKE ?", "%"+namaProduk+"%")
 	}
 
+	// Category filters also match products listing the category as secondary
 	if categoryID, ok := filters["category_id"].(int); ok && categoryID > 0 {
-		query = query.Where("id_category = ?", categoryID)
+		query = query.Where("(id_category = ? OR id IN (SELECT id_produk FROM produk_category WHERE id_category = ?))", categoryID, categoryID)
+	}
+
+	if categoryIDs, ok := filters["category_ids"].([]int); ok && len(categoryIDs) > 0 {
+		query = query.Where("(id_category IN ? OR id IN (SELECT id_produk FROM produk_category WHERE id_category IN ?))", categoryIDs, categoryIDs)
+	}
+
+	// Every listed tag must be present on the product
+	if tags, ok := filters["tags"].([]string); ok {
+		for _, tag := range tags {
+			query = query.Where("id IN (SELECT produk_tag.id_produk FROM produk_tag JOIN tag ON tag.id = produk_tag.id_tag WHERE tag.slug = ?)", tag)
+		}
+	}
+
+	// Each attribute value lists its accepted stored forms
+	if attributes, ok := filters["attributes"].(map[string][]string); ok {
+		for name, values := range attributes {
+			query = query.Where("id IN (SELECT id_produk FROM produk_attribute WHERE name = ? AND value IN ?)", name, values)
+		}
 	}
 
 	if tokoID, ok := filters["toko_id"].(int); ok && tokoID > 0 {
@@ -90,8 +223,7 @@ func (r *produkRepository) FindAll(limit, offset int, filters map[string]interfa
 		query = query.Where("CAST(harga_konsumen AS UNSIGNED) <= ?", maxHarga)
 	}
 
-	err := query.Find(&produks).Error
-	return produks, err
+	return query
 }
 
 func (r *produkRepository) Update(produk *model.Produk) error {
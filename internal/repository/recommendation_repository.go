@@ -0,0 +1,112 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : recommendation_repository.go
+// Description  : Repository layer untuk rekomendasi produk terkait
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi perhitungan pasangan co-purchase dan query produk terkait
+// - Co-purchase dihitung ulang seluruhnya dalam satu transaksi database
+// - Hanya produk yang tampil publik, belum dihapus, dan tersedia yang dikembalikan
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecommendationRepository interface
+type RecommendationRepository interface {
+	RefreshCoPurchases(since time.Time) (int64, error)
+	FindCoPurchased(produkID int, limit int) ([]model.Produk, error)
+	FindSimilar(produk *model.Produk, excludeIDs []int, limit int) ([]model.Produk, error)
+}
+
+type recommendationRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommendationRepository creates new recommendation repository
+func NewRecommendationRepository(db *gorm.DB) RecommendationRepository {
+	return &recommendationRepository{db: db}
+}
+
+// RefreshCoPurchases rebuilds the co-purchase pairs from transactions created
+// since the given time and returns the number of pairs stored
+func (r *recommendationRepository) RefreshCoPurchases(since time.Time) (int64, error) {
+	var rows int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM produk_co_purchase").Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(
+			"INSERT INTO produk_co_purchase (id_produk, id_related, score, updated_at) "+
+				"SELECT la.id_produk, lb.id_produk, COUNT(DISTINCT da.id_trx), ? "+
+				"FROM detail_trx da "+
+				"JOIN log_produk la ON la.id = da.id_log_produk "+
+				"JOIN detail_trx db ON db.id_trx = da.id_trx AND db.id <> da.id "+
+				"JOIN log_produk lb ON lb.id = db.id_log_produk "+
+				"WHERE da.created_at >= ? AND la.id_produk <> lb.id_produk "+
+				"GROUP BY la.id_produk, lb.id_produk",
+			time.Now(), since,
+		)
+		if result.Error != nil {
+			return result.Error
+		}
+		rows = result.RowsAffected
+		return nil
+	})
+	return rows, err
+}
+
+// FindCoPurchased returns products most often bought together with produkID
+func (r *recommendationRepository) FindCoPurchased(produkID int, limit int) ([]model.Produk, error) {
+	var produks []model.Produk
+	err := r.db.Scopes(relatedCandidate).
+		Joins("JOIN produk_co_purchase ON produk_co_purchase.id_related = produk.id").
+		Where("produk_co_purchase.id_produk = ?", produkID).
+		Order("produk_co_purchase.score DESC, produk.id DESC").
+		Limit(limit).
+		Find(&produks).Error
+	return produks, err
+}
+
+// FindSimilar returns products of the same category first, then of the same
+// toko, best rated first
+func (r *recommendationRepository) FindSimilar(produk *model.Produk, excludeIDs []int, limit int) ([]model.Produk, error) {
+	var produks []model.Produk
+	query := r.db.Scopes(relatedCandidate).
+		Where("produk.id <> ? AND (produk.id_category = ? OR produk.id_toko = ?)", produk.ID, produk.IDCategory, produk.IDToko)
+	if len(excludeIDs) > 0 {
+		query = query.Where("produk.id NOT IN ?", excludeIDs)
+	}
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "produk.id_category = ? DESC, produk.rating_avg DESC, produk.id DESC",
+			Vars: []interface{}{produk.IDCategory},
+		}}).
+		Limit(limit).
+		Find(&produks).Error
+	return produks, err
+}
+
+// relatedCandidate limits products to visible, live ones that can be ordered,
+// bundle stock is derived from components and checked by the caller while
+// digital products need no stock
+func relatedCandidate(db *gorm.DB) *gorm.DB {
+	return db.Select("produk.*").
+		Where("produk.deleted_at IS NULL").
+		Scopes(visibleProduk(0)).
+		Where("(produk.stok > 0 OR produk.is_preorder = 1 OR produk.type IN ?)", []string{model.ProdukTypeBundle, model.ProdukTypeDigital}).
+		Preload("Toko").Preload("Photos", orderedPhotos).Preload("BundleItems.Component")
+}
@@ -0,0 +1,77 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : review_repository.go
+// Description  : Repository layer untuk operasi database Review
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk CRUD Review
+// - Menggunakan GORM sebagai ORM
+// - Review yang disembunyikan admin tidak ikut dalam listing publik
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ReviewRepository interface
+type ReviewRepository interface {
+	Create(review *model.Review) error
+	FindByID(id int) (*model.Review, error)
+	ExistsByDetailTrxID(detailTrxID int) (bool, error)
+	FindVisibleByProdukID(produkID int, limit, offset int) ([]model.Review, error)
+	Update(review *model.Review) error
+}
+
+type reviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates new review repository
+func NewReviewRepository(db *gorm.DB) ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+func (r *reviewRepository) Create(review *model.Review) error {
+	return r.db.Create(review).Error
+}
+
+func (r *reviewRepository) FindByID(id int) (*model.Review, error) {
+	var review model.Review
+	err := r.db.First(&review, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *reviewRepository) ExistsByDetailTrxID(detailTrxID int) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Review{}).Where("id_detail_trx = ?", detailTrxID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *reviewRepository) FindVisibleByProdukID(produkID int, limit, offset int) ([]model.Review, error) {
+	var reviews []model.Review
+	err := r.db.Where("id_produk = ? AND is_hidden = ?", produkID, false).
+		Preload("Photos").
+		Order("id DESC").
+		Limit(limit).Offset(offset).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+func (r *reviewRepository) Update(review *model.Review) error {
+	return r.db.Save(review).Error
+}
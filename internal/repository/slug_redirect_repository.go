@@ -0,0 +1,52 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : slug_redirect_repository.go
+// Description  : Repository layer untuk operasi database SlugRedirect
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk lookup slug lama
+// - Pencatatan redirect dilakukan di dalam transaksi usecase
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// SlugRedirectRepository interface
+type SlugRedirectRepository interface {
+	FindBySlug(entity string, scopeID int, slug string) (*model.SlugRedirect, error)
+	FindAllBySlug(entity string, slug string) ([]model.SlugRedirect, error)
+}
+
+type slugRedirectRepository struct {
+	db *gorm.DB
+}
+
+// NewSlugRedirectRepository creates new slug redirect repository
+func NewSlugRedirectRepository(db *gorm.DB) SlugRedirectRepository {
+	return &slugRedirectRepository{db: db}
+}
+
+func (r *slugRedirectRepository) FindBySlug(entity string, scopeID int, slug string) (*model.SlugRedirect, error) {
+	var redirect model.SlugRedirect
+	err := r.db.Where("entity = ? AND id_scope = ? AND slug = ?", entity, scopeID, slug).First(&redirect).Error
+	if err != nil {
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+func (r *slugRedirectRepository) FindAllBySlug(entity string, slug string) ([]model.SlugRedirect, error) {
+	var redirects []model.SlugRedirect
+	err := r.db.Where("entity = ? AND slug = ?", entity, slug).Find(&redirects).Error
+	return redirects, err
+}
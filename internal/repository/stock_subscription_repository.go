@@ -0,0 +1,67 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : stock_subscription_repository.go
+// Description  : Repository layer untuk operasi database StockSubscription
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk langganan stok
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// StockSubscriptionRepository interface
+type StockSubscriptionRepository interface {
+	Create(subscription *model.StockSubscription) error
+	FindByUserAndProduk(userID, produkID int) (*model.StockSubscription, error)
+	FindByUserID(userID int, limit, offset int) ([]model.StockSubscription, error)
+	Delete(id int) error
+}
+
+type stockSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewStockSubscriptionRepository creates new stock subscription repository
+func NewStockSubscriptionRepository(db *gorm.DB) StockSubscriptionRepository {
+	return &stockSubscriptionRepository{db: db}
+}
+
+func (r *stockSubscriptionRepository) Create(subscription *model.StockSubscription) error {
+	return r.db.Create(subscription).Error
+}
+
+func (r *stockSubscriptionRepository) FindByUserAndProduk(userID, produkID int) (*model.StockSubscription, error) {
+	var subscription model.StockSubscription
+	err := r.db.Where("id_user = ? AND id_produk = ?", userID, produkID).First(&subscription).Error
+	if err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *stockSubscriptionRepository) FindByUserID(userID int, limit, offset int) ([]model.StockSubscription, error) {
+	var subscriptions []model.StockSubscription
+	err := r.db.Where("id_user = ?", userID).
+		Preload("Produk").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *stockSubscriptionRepository) Delete(id int) error {
+	return r.db.Delete(&model.StockSubscription{}, id).Error
+}
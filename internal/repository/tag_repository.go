@@ -0,0 +1,78 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : tag_repository.go
+// Description  : Repository layer untuk operasi database Tag
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk tag produk
+// - Jumlah produk per tag hanya menghitung produk yang tampil publik
+// - Menggunakan GORM sebagai ORM
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// TagRepository interface
+type TagRepository interface {
+	FindByID(id int) (*model.Tag, error)
+	FindBySlug(slug string) (*model.Tag, error)
+	FindCounts(limit int) ([]model.TagCount, error)
+	Update(tag *model.Tag) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates new tag repository
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) FindByID(id int) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.First(&tag, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (r *tagRepository) FindBySlug(slug string) (*model.Tag, error) {
+	var tag model.Tag
+	err := r.db.Where("slug = ?", slug).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// FindCounts returns tags ordered by the number of visible products
+func (r *tagRepository) FindCounts(limit int) ([]model.TagCount, error) {
+	visible := r.db.Model(&model.Produk{}).Select("id").Where("deleted_at IS NULL").Scopes(visibleProduk(0))
+
+	var counts []model.TagCount
+	err := r.db.Table("tag").
+		Select("tag.id, tag.name, tag.slug, COUNT(produk_tag.id_produk) AS produk_count").
+		Joins("JOIN produk_tag ON produk_tag.id_tag = tag.id").
+		Where("produk_tag.id_produk IN (?)", visible).
+		Group("tag.id, tag.name, tag.slug").
+		Order("produk_count DESC, tag.name ASC").
+		Limit(limit).
+		Scan(&counts).Error
+	return counts, err
+}
+
+func (r *tagRepository) Update(tag *model.Tag) error {
+	return r.db.Save(tag).Error
+}
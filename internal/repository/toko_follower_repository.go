@@ -0,0 +1,81 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : toko_follower_repository.go
+// Description  : Repository layer untuk follower toko dan feed produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk follower toko
+// - Feed berisi produk yang sudah tampil dari toko yang diikuti, diurutkan
+//   dari publish_at terbaru dengan keyset pagination
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// TokoFollowerRepository interface
+type TokoFollowerRepository interface {
+	FindByUserAndToko(userID, tokoID int) (*model.TokoFollower, error)
+	FindByUserID(userID int, limit, offset int) ([]model.TokoFollower, error)
+	FindFeedByCursor(userID int, limit int, cursor *utils.Cursor) ([]model.Produk, error)
+}
+
+type tokoFollowerRepository struct {
+	db *gorm.DB
+}
+
+// NewTokoFollowerRepository creates new toko follower repository
+func NewTokoFollowerRepository(db *gorm.DB) TokoFollowerRepository {
+	return &tokoFollowerRepository{db: db}
+}
+
+func (r *tokoFollowerRepository) FindByUserAndToko(userID, tokoID int) (*model.TokoFollower, error) {
+	var follower model.TokoFollower
+	err := r.db.Where("id_user = ? AND id_toko = ?", userID, tokoID).First(&follower).Error
+	if err != nil {
+		return nil, err
+	}
+	return &follower, nil
+}
+
+// FindByUserID lists the tokos followed by the user, latest followed first
+func (r *tokoFollowerRepository) FindByUserID(userID int, limit, offset int) ([]model.TokoFollower, error) {
+	var followers []model.TokoFollower
+	err := r.db.Where("id_user = ?", userID).
+		Preload("Toko").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&followers).Error
+	return followers, err
+}
+
+// FindFeedByCursor lists visible products of the tokos followed by the user,
+// most recently published first
+func (r *tokoFollowerRepository) FindFeedByCursor(userID int, limit int, cursor *utils.Cursor) ([]model.Produk, error) {
+	followed := r.db.Model(&model.TokoFollower{}).Select("id_toko").Where("id_user = ?", userID)
+
+	query := r.db.Where("id_toko IN (?)", followed).
+		Where("deleted_at IS NULL AND publish_at IS NOT NULL").
+		Scopes(visibleProduk(0)).
+		Preload("Toko").Preload("Category").Preload("Photos", orderedPhotos).Preload("BundleItems.Component")
+
+	query, err := applyTimeCursor(query, "publish_at", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var produks []model.Produk
+	err = query.Find(&produks).Error
+	return produks, err
+}
@@ -18,6 +18,7 @@ package repository
 
 import (
 	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
 
 	"gorm.io/gorm"
 )
@@ -27,7 +28,9 @@ type TokoRepository interface {
 	Create(toko *model.Toko) error
 	FindByID(id int) (*model.Toko, error)
 	FindByUserID(userID int) (*model.Toko, error)
+	FindBySlug(slug string) (*model.Toko, error)
 	FindAll(limit, offset int, nama string) ([]model.Toko, error)
+	FindAllByCursor(limit int, cursor *utils.Cursor, nama string) ([]model.Toko, error)
 	Update(toko *model.Toko) error
 	Delete(id int) error
 }
@@ -63,6 +66,15 @@ func (r *tokoRepository) FindByUserID(userID int) (*model.Toko, error) {
 	return &toko, nil
 }
 
+func (r *tokoRepository) FindBySlug(slug string) (*model.Toko, error) {
+	var toko model.Toko
+	err := r.db.Where("slug = ?", slug).First(&toko).Error
+	if err != nil {
+		return nil, err
+	}
+	return &toko, nil
+}
+
 func (r *tokoRepository) FindAll(limit, offset int, nama string) ([]model.Toko, error) {
 	var tokos []model.Toko
 	query := r.db.Limit(limit).Offset(offset)
@@ -75,6 +87,23 @@ func (r *tokoRepository) FindAll(limit, offset int, nama string) ([]model.Toko,
 	return tokos, err
 }
 
+func (r *tokoRepository) FindAllByCursor(limit int, cursor *utils.Cursor, nama string) ([]model.Toko, error) {
+	var tokos []model.Toko
+	query := r.db
+
+	if nama != "" {
+		query = query.Where("nama_toko LIKE ?", "%"+nama+"%")
+	}
+
+	query, err := applyCursor(query, "id", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&tokos).Error
+	return tokos, err
+}
+
 func (r *tokoRepository) Update(toko *model.Toko) error {
 	return r.db.Save(toko).Error
 }
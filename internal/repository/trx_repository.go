@@ -18,6 +18,7 @@ package repository
 
 import (
 	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
 
 	"gorm.io/gorm"
 )
@@ -28,6 +29,7 @@ type TrxRepository interface {
 	FindByID(id int) (*model.Trx, error)
 	FindByIDWithDetails(id int) (*model.Trx, error)
 	FindByUserID(userID int, limit, offset int) ([]model.Trx, error)
+	FindByUserIDByCursor(userID int, limit int, cursor *utils.Cursor) ([]model.Trx, error)
 	Update(trx *model.Trx) error
 }
 
@@ -72,6 +74,21 @@ func (r *trxRepository) FindByUserID(userID int, limit, offset int) ([]model.Trx
 	return trxs, err
 }
 
+func (r *trxRepository) FindByUserIDByCursor(userID int, limit int, cursor *utils.Cursor) ([]model.Trx, error) {
+	var trxs []model.Trx
+	query := r.db.Where("id_user = ?", userID).
+		Preload("DetailTrx.LogProduk").
+		Preload("DetailTrx.Toko")
+
+	query, err := applyCursor(query, "id", cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = query.Find(&trxs).Error
+	return trxs, err
+}
+
 func (r *trxRepository) Update(trx *model.Trx) error {
 	return r.db.Save(trx).Error
 }
@@ -0,0 +1,105 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : wishlist_repository.go
+// Description  : Repository layer untuk operasi database Wishlist
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface dan implementasi untuk CRUD Wishlist
+// - Daftar wishlist hanya memuat produk yang masih tampil publik
+// - Menyediakan hitungan wishlist per produk untuk penjual
+//
+// ============================================================================
+
+package repository
+
+import (
+	"evermos-api/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// WishlistRepository interface
+type WishlistRepository interface {
+	Create(wishlist *model.Wishlist) error
+	FindByUserAndProduk(userID, produkID int) (*model.Wishlist, error)
+	FindByUserID(userID int, limit, offset int) ([]model.Wishlist, error)
+	FindWishlistedIDs(userID int, produkIDs []int) ([]int, error)
+	CountByProdukIDs(produkIDs []int) (map[int]int, error)
+	Delete(id int) error
+}
+
+type wishlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWishlistRepository creates new wishlist repository
+func NewWishlistRepository(db *gorm.DB) WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+func (r *wishlistRepository) Create(wishlist *model.Wishlist) error {
+	return r.db.Create(wishlist).Error
+}
+
+func (r *wishlistRepository) FindByUserAndProduk(userID, produkID int) (*model.Wishlist, error) {
+	var wishlist model.Wishlist
+	err := r.db.Where("id_user = ? AND id_produk = ?", userID, produkID).First(&wishlist).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wishlist, nil
+}
+
+// FindByUserID lists the user's wishlist, newest first, skipping products
+// that were deleted or are no longer visible
+func (r *wishlistRepository) FindByUserID(userID int, limit, offset int) ([]model.Wishlist, error) {
+	var wishlists []model.Wishlist
+	visible := r.db.Model(&model.Produk{}).Select("id").Where("deleted_at IS NULL").Scopes(visibleProduk(0))
+	err := r.db.Where("id_user = ? AND id_produk IN (?)", userID, visible).
+		Preload("Produk.Toko").Preload("Produk.Category").Preload("Produk.Photos", orderedPhotos).
+		Preload("Produk.Attributes").Preload("Produk.Tags").Preload("Produk.Categories").
+		Preload("Produk.BundleItems.Component").
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&wishlists).Error
+	return wishlists, err
+}
+
+// FindWishlistedIDs returns which of the given products the user wishlisted
+func (r *wishlistRepository) FindWishlistedIDs(userID int, produkIDs []int) ([]int, error) {
+	var ids []int
+	err := r.db.Model(&model.Wishlist{}).
+		Where("id_user = ? AND id_produk IN ?", userID, produkIDs).
+		Pluck("id_produk", &ids).Error
+	return ids, err
+}
+
+// CountByProdukIDs counts how many users wishlisted each product
+func (r *wishlistRepository) CountByProdukIDs(produkIDs []int) (map[int]int, error) {
+	var rows []struct {
+		IDProduk int
+		Total    int
+	}
+	err := r.db.Model(&model.Wishlist{}).
+		Select("id_produk, COUNT(*) AS total").
+		Where("id_produk IN ?", produkIDs).
+		Group("id_produk").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		counts[row.IDProduk] = row.Total
+	}
+	return counts, nil
+}
+
+func (r *wishlistRepository) Delete(id int) error {
+	return r.db.Delete(&model.Wishlist{}, id).Error
+}
@@ -0,0 +1,100 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : local.go
+// Description  : Implementasi Storage pada local filesystem
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini menyimpan file di bawah direktori UPLOAD_PATH
+// - Direktori dibuat otomatis saat file ditulis
+// - Hanya cocok untuk satu instance API (tidak dibagi antar container)
+//
+// ============================================================================
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStorage struct {
+	root string
+}
+
+// NewLocalStorage creates storage backed by a local directory
+func NewLocalStorage(root string) (Storage, error) {
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create uploads directory: %w", err)
+	}
+	return &localStorage{root: root}, nil
+}
+
+func (s *localStorage) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(CleanKey(key)))
+}
+
+func (s *localStorage) Put(key string, data []byte, contentType string) error {
+	filePath := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+func (s *localStorage) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (s *localStorage) Stat(key string) (*ObjectInfo, error) {
+	info, err := os.Stat(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ObjectInfo{Key: CleanKey(key), Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (s *localStorage) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *localStorage) List(prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, err
+}
@@ -0,0 +1,58 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : storage.go
+// Description  : Abstraksi penyimpanan file upload
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi interface Storage untuk menyimpan file upload
+// - Implementasi tersedia untuk local filesystem dan S3-compatible
+// - Key file berupa path relatif dengan separator "/" (mis. produk/abc.jpg)
+//
+// ============================================================================
+
+package storage
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when an object does not exist
+var ErrNotFound = errors.New("file not found")
+
+// ObjectInfo holds object metadata
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage interface
+type Storage interface {
+	Put(key string, data []byte, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Stat(key string) (*ObjectInfo, error)
+	Delete(key string) error
+	List(prefix string) ([]string, error)
+}
+
+// CleanKey normalizes key and prevents escaping the storage root
+func CleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+}
+
+// ContentType guesses content type from key extension
+func ContentType(key string) string {
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
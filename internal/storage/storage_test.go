@@ -0,0 +1,135 @@
+package storage_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"evermos-api/internal/storage"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 is a minimal in-memory stand-in for a MinIO/S3 bucket (path-style)
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	sum := sha256.Sum256(body)
+	if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/"+f.bucket+"/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		type content struct {
+			Key string `xml:"Key"`
+		}
+		var result struct {
+			XMLName  xml.Name  `xml:"ListBucketResult"`
+			Contents []content `xml:"Contents"`
+		}
+		prefix := r.URL.Query().Get("prefix")
+		for k := range f.objects {
+			if strings.HasPrefix(k, prefix) {
+				result.Contents = append(result.Contents, content{Key: k})
+			}
+		}
+		_ = xml.NewEncoder(w).Encode(result)
+	case r.Method == http.MethodPut:
+		f.objects[key] = body
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		data, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Write(data)
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func runStorageContract(t *testing.T, store storage.Storage) {
+	require.NoError(t, store.Put("produk/a.jpg", []byte("aaa"), "image/jpeg"))
+	require.NoError(t, store.Put("toko/b.jpg", []byte("bb"), "image/jpeg"))
+
+	reader, err := store.Get("produk/a.jpg")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(reader)
+	reader.Close()
+	assert.Equal(t, "aaa", string(data))
+
+	info, err := store.Stat("toko/b.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), info.Size)
+
+	keys, err := store.List("")
+	require.NoError(t, err)
+	sort.Strings(keys)
+	assert.Equal(t, []string{"produk/a.jpg", "toko/b.jpg"}, keys)
+
+	keys, err = store.List("toko/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"toko/b.jpg"}, keys)
+
+	require.NoError(t, store.Delete("produk/a.jpg"))
+	require.NoError(t, store.Delete("produk/a.jpg"))
+
+	_, err = store.Get("produk/a.jpg")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = store.Stat("produk/a.jpg")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestLocalStorage(t *testing.T) {
+	store, err := storage.NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	runStorageContract(t, store)
+}
+
+func TestS3Storage(t *testing.T) {
+	server := httptest.NewServer(&fakeS3{bucket: "goshop", objects: map[string][]byte{}})
+	defer server.Close()
+
+	store, err := storage.NewS3Storage(storage.S3Options{
+		Endpoint:     server.URL,
+		Bucket:       "goshop",
+		AccessKey:    "test-key",
+		SecretKey:    "test-secret",
+		UsePathStyle: true,
+	})
+	require.NoError(t, err)
+
+	runStorageContract(t, store)
+}
+
+func TestCleanKey(t *testing.T) {
+	assert.Equal(t, "etc/passwd", storage.CleanKey("../../etc/passwd"))
+	assert.Equal(t, "produk/a.jpg", storage.CleanKey("produk\\a.jpg"))
+}
@@ -0,0 +1,194 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : attribute.go
+// Description  : Helper skema atribut kategori dan validasi atribut produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk membaca skema atribut efektif sebuah kategori
+// - Skema efektif = atribut kategori itu sendiri + seluruh kategori induknya
+// - Nilai atribut produk divalidasi dan dinormalisasi sesuai tipe atribut
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// attributeValueMaxLength follows the produk_attribute.value column size
+const attributeValueMaxLength = 255
+
+// effectiveAttributes returns the attribute schema of a category including
+// the attributes inherited from its ancestors
+func effectiveAttributes(categoryRepo repository.CategoryRepository, attributeRepo repository.CategoryAttributeRepository, categoryID int) ([]model.CategoryAttribute, error) {
+	categories, err := categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	path := newCategoryIndex(categories).breadcrumb(categoryID)
+	if len(path) == 0 {
+		return nil, errors.New("category not found")
+	}
+
+	ids := make([]int, 0, len(path))
+	for _, crumb := range path {
+		ids = append(ids, crumb.ID)
+	}
+	return attributeRepo.FindByCategoryIDs(ids)
+}
+
+// parseAttributeValues decodes the attributes form field, a JSON object of
+// attribute name to string, number, or boolean value
+func parseAttributeValues(raw string) (map[string]string, error) {
+	values := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return values, nil
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return nil, errors.New("attributes must be a JSON object")
+	}
+
+	for name, value := range decoded {
+		switch v := value.(type) {
+		case string:
+			values[name] = v
+		case float64:
+			values[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			values[name] = strconv.FormatBool(v)
+		case nil:
+			// Null is treated as not provided
+		default:
+			return nil, fmt.Errorf("attribute %s must be a string, number, or boolean", name)
+		}
+	}
+	return values, nil
+}
+
+// validateAttributeValues checks values against the schema and returns the
+// normalized rows to store, unknown and missing required attributes are rejected
+func validateAttributeValues(schema []model.CategoryAttribute, values map[string]string) ([]model.ProdukAttribute, error) {
+	byName := make(map[string]model.CategoryAttribute, len(schema))
+	for _, attribute := range schema {
+		byName[attribute.Name] = attribute
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []string
+	attributes := make([]model.ProdukAttribute, 0, len(values))
+	for _, name := range names {
+		attribute, ok := byName[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("attribute %s is not defined for this category", name))
+			continue
+		}
+
+		raw := strings.TrimSpace(values[name])
+		if raw == "" {
+			if attribute.Required {
+				errs = append(errs, fmt.Sprintf("attribute %s is required", name))
+			}
+			continue
+		}
+
+		value, err := normalizeAttributeValue(attribute, raw)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		attributes = append(attributes, model.ProdukAttribute{Name: name, Value: value})
+	}
+
+	for _, attribute := range schema {
+		if _, ok := values[attribute.Name]; !ok && attribute.Required {
+			errs = append(errs, fmt.Sprintf("attribute %s is required", attribute.Name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return attributes, nil
+}
+
+// normalizeAttributeValue converts a value to the canonical form of its type
+// so that filtering matches regardless of how the value was written
+func normalizeAttributeValue(attribute model.CategoryAttribute, value string) (string, error) {
+	switch attribute.Type {
+	case model.AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("attribute %s must be a number", attribute.Name)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case model.AttributeTypeBoolean:
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("attribute %s must be true or false", attribute.Name)
+		}
+		return strconv.FormatBool(flag), nil
+	case model.AttributeTypeDate:
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", fmt.Errorf("attribute %s must be a date (YYYY-MM-DD)", attribute.Name)
+		}
+		return date.Format("2006-01-02"), nil
+	case model.AttributeTypeEnum:
+		for _, allowed := range attribute.AllowedValues {
+			if value == allowed {
+				return value, nil
+			}
+		}
+		return "", fmt.Errorf("attribute %s must be one of: %s", attribute.Name, strings.Join(attribute.AllowedValues, ", "))
+	default:
+		if utf8.RuneCountInString(value) > attributeValueMaxLength {
+			return "", fmt.Errorf("attribute %s must be at most %d characters", attribute.Name, attributeValueMaxLength)
+		}
+		return value, nil
+	}
+}
+
+// attributeFilterValues returns the stored forms a filter value can match,
+// filters span categories so the attribute type is unknown and the value is
+// matched as written and in its canonical number and boolean forms
+func attributeFilterValues(value string) []string {
+	values := []string{value}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		values = append(values, strconv.FormatFloat(number, 'f', -1, 64))
+	}
+	if flag, err := strconv.ParseBool(value); err == nil {
+		values = append(values, strconv.FormatBool(flag))
+	}
+	return values
+}
+
+// attributeValues converts stored product attributes back to a name/value map
+func attributeValues(attributes []model.ProdukAttribute) map[string]string {
+	values := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		values[attribute.Name] = attribute.Value
+	}
+	return values
+}
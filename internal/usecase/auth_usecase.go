@@ -116,11 +116,10 @@ func (u *authUsecase) Register(req model.RegisterRequest) error {
 			UpdatedAt: &now,
 		}
 
-		if err := tx.Create(toko).Error; err != nil {
-			return err
-		}
-
-		return nil
+		return saveWithSlug(tx, model.SlugEntityToko, 0, 0, tokoName, func(slug string) error {
+			toko.Slug = slug
+			return tx.Create(toko).Error
+		})
 	})
 }
 
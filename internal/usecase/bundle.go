@@ -0,0 +1,188 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : bundle.go
+// Description  : Helper produk bundle (paket)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk menyusun, menghitung stok, dan mengurangi
+//   stok komponen produk bundle
+// - Komponen bundle harus produk single fisik (bukan pre-order atau digital)
+//   dari toko yang sama
+// - Stok dikurangi dengan update bersyarat agar tidak pernah minus
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"evermos-api/internal/model"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// maxBundleItems limits the number of distinct components of a bundle
+const maxBundleItems = 20
+
+// parseBundleItems decodes the bundle_items form field, a JSON array of
+// {"product_id", "kuantitas"}, repeated products are merged
+func parseBundleItems(raw string) ([]model.BundleItemRequest, error) {
+	var decoded []model.BundleItemRequest
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return nil, errors.New("bundle_items must be a JSON array")
+	}
+
+	var items []model.BundleItemRequest
+	index := make(map[int]int)
+	units := 0
+	for _, item := range decoded {
+		if item.ProductID <= 0 || item.Kuantitas <= 0 {
+			return nil, errors.New("bundle item requires product_id and a positive kuantitas")
+		}
+		units += item.Kuantitas
+		if i, ok := index[item.ProductID]; ok {
+			items[i].Kuantitas += item.Kuantitas
+			continue
+		}
+		index[item.ProductID] = len(items)
+		items = append(items, item)
+	}
+
+	if units < 2 {
+		return nil, errors.New("a bundle must contain at least 2 items")
+	}
+	if len(items) > maxBundleItems {
+		return nil, fmt.Errorf("a bundle can have at most %d different products", maxBundleItems)
+	}
+	return items, nil
+}
+
+// bundleItems validates the components of a bundle owned by tokoID
+func (u *produkUsecase) bundleItems(tokoID, bundleID int, raw string) ([]model.BundleItem, error) {
+	requests, err := parseBundleItems(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]model.BundleItem, 0, len(requests))
+	for _, req := range requests {
+		component, err := u.produkRepo.FindByID(req.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("bundle component not found: %d", req.ProductID)
+		}
+		if component.IDToko != tokoID {
+			return nil, fmt.Errorf("bundle component %d belongs to another toko", req.ProductID)
+		}
+		if component.ID == bundleID || component.Type == model.ProdukTypeBundle {
+			return nil, errors.New("a bundle cannot contain another bundle")
+		}
+		if component.IsPreorder {
+			return nil, errors.New("a bundle cannot contain a pre-order product")
+		}
+		if component.Type == model.ProdukTypeDigital {
+			return nil, errors.New("a bundle cannot contain a digital product")
+		}
+		items = append(items, model.BundleItem{IDProduk: component.ID, Kuantitas: req.Kuantitas})
+	}
+	return items, nil
+}
+
+// replaceBundleItems swaps the components of a bundle
+func replaceBundleItems(tx *gorm.DB, bundleID int, items []model.BundleItem) error {
+	if err := tx.Where("id_bundle = ?", bundleID).Delete(&model.BundleItem{}).Error; err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	for i := range items {
+		items[i].ID = 0
+		items[i].IDBundle = bundleID
+	}
+	return tx.Create(&items).Error
+}
+
+// countBundlesContaining counts live bundles using a product as component
+func countBundlesContaining(db *gorm.DB, produkID int) (int64, error) {
+	var count int64
+	err := db.Model(&model.BundleItem{}).
+		Joins("JOIN produk ON produk.id = bundle_item.id_bundle").
+		Where("bundle_item.id_produk = ? AND produk.deleted_at IS NULL", produkID).
+		Count(&count).Error
+	return count, err
+}
+
+// bundleStock returns how many bundles can be assembled from the component
+// stock, components must be preloaded
+func bundleStock(produk model.Produk) int {
+	if len(produk.BundleItems) == 0 {
+		return 0
+	}
+
+	stock := -1
+	for _, item := range produk.BundleItems {
+		if item.Component == nil || item.Component.DeletedAt != nil || item.Kuantitas <= 0 {
+			return 0
+		}
+		if available := item.Component.Stok / item.Kuantitas; stock == -1 || available < stock {
+			stock = available
+		}
+	}
+	return stock
+}
+
+// availableStock returns the sellable stock of a product
+func availableStock(produk model.Produk) int {
+	if produk.Type == model.ProdukTypeBundle {
+		return bundleStock(produk)
+	}
+	return produk.Stok
+}
+
+// fillBundleStock exposes the derived stock of bundles
+func fillBundleStock(produks []model.Produk) {
+	for i := range produks {
+		if produks[i].Type == model.ProdukTypeBundle {
+			produks[i].Stok = bundleStock(produks[i])
+		}
+	}
+}
+
+// bundleSnapshot captures the components of a bundle as sold
+func bundleSnapshot(produk *model.Produk) []model.BundleSnapshot {
+	if produk.Type != model.ProdukTypeBundle {
+		return nil
+	}
+
+	snapshot := make([]model.BundleSnapshot, 0, len(produk.BundleItems))
+	for _, item := range produk.BundleItems {
+		entry := model.BundleSnapshot{IDProduk: item.IDProduk, Kuantitas: item.Kuantitas}
+		if item.Component != nil {
+			entry.NamaProduk = item.Component.NamaProduk
+			entry.HargaKonsumen = item.Component.HargaKonsumen
+		}
+		snapshot = append(snapshot, entry)
+	}
+	return snapshot
+}
+
+// decrementStock takes kuantitas from a product's stock inside tx, failing
+// instead of going negative when concurrent orders already took it
+func decrementStock(tx *gorm.DB, produkID, kuantitas int, nama string) error {
+	result := tx.Model(&model.Produk{}).
+		Where("id = ? AND stok >= ?", produkID, kuantitas).
+		Update("stok", gorm.Expr("stok - ?", kuantitas))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("insufficient stock for product: " + nama)
+	}
+	return nil
+}
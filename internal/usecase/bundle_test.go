@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBundleItems(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []model.BundleItemRequest
+		wantErr  string
+	}{
+		{
+			name: "Two products",
+			raw:  `[{"product_id":1,"kuantitas":1},{"product_id":2,"kuantitas":3}]`,
+			expected: []model.BundleItemRequest{
+				{ProductID: 1, Kuantitas: 1},
+				{ProductID: 2, Kuantitas: 3},
+			},
+		},
+		{
+			name: "Repeated products are merged",
+			raw:  `[{"product_id":1,"kuantitas":1},{"product_id":2,"kuantitas":1},{"product_id":1,"kuantitas":2}]`,
+			expected: []model.BundleItemRequest{
+				{ProductID: 1, Kuantitas: 3},
+				{ProductID: 2, Kuantitas: 1},
+			},
+		},
+		{
+			name:     "Two units of one product",
+			raw:      `[{"product_id":1,"kuantitas":2}]`,
+			expected: []model.BundleItemRequest{{ProductID: 1, Kuantitas: 2}},
+		},
+		{
+			name:     "Repeated single units reach the minimum",
+			raw:      `[{"product_id":1,"kuantitas":1},{"product_id":1,"kuantitas":1}]`,
+			expected: []model.BundleItemRequest{{ProductID: 1, Kuantitas: 2}},
+		},
+		{name: "Single unit", raw: `[{"product_id":1,"kuantitas":1}]`, wantErr: "a bundle must contain at least 2 items"},
+		{name: "Empty", raw: `[]`, wantErr: "a bundle must contain at least 2 items"},
+		{name: "Zero kuantitas", raw: `[{"product_id":1,"kuantitas":0},{"product_id":2,"kuantitas":2}]`, wantErr: "bundle item requires product_id and a positive kuantitas"},
+		{name: "Missing product", raw: `[{"kuantitas":2}]`, wantErr: "bundle item requires product_id and a positive kuantitas"},
+		{name: "Not an array", raw: `{"product_id":1}`, wantErr: "bundle_items must be a JSON array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := parseBundleItems(tt.raw)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, items)
+		})
+	}
+}
+
+func TestParseBundleItems_MaxItems(t *testing.T) {
+	parts := make([]string, maxBundleItems+1)
+	for i := range parts {
+		parts[i] = fmt.Sprintf(`{"product_id":%d,"kuantitas":1}`, i+1)
+	}
+
+	_, err := parseBundleItems("[" + strings.Join(parts, ",") + "]")
+
+	assert.EqualError(t, err, fmt.Sprintf("a bundle can have at most %d different products", maxBundleItems))
+}
+
+func TestBundleStock(t *testing.T) {
+	deletedAt := time.Now()
+	component := func(stok int) *model.Produk {
+		return &model.Produk{Stok: stok}
+	}
+
+	tests := []struct {
+		name     string
+		items    []model.BundleItem
+		expected int
+	}{
+		{
+			name: "Limited by the scarcest component",
+			items: []model.BundleItem{
+				{Kuantitas: 2, Component: component(10)},
+				{Kuantitas: 1, Component: component(3)},
+			},
+			expected: 3,
+		},
+		{
+			name: "Stock is divided and rounded down",
+			items: []model.BundleItem{
+				{Kuantitas: 3, Component: component(10)},
+				{Kuantitas: 1, Component: component(50)},
+			},
+			expected: 3,
+		},
+		{
+			name: "Not enough for one bundle",
+			items: []model.BundleItem{
+				{Kuantitas: 4, Component: component(3)},
+				{Kuantitas: 1, Component: component(50)},
+			},
+			expected: 0,
+		},
+		{
+			name: "Deleted component",
+			items: []model.BundleItem{
+				{Kuantitas: 1, Component: &model.Produk{Stok: 10, DeletedAt: &deletedAt}},
+				{Kuantitas: 1, Component: component(10)},
+			},
+			expected: 0,
+		},
+		{
+			name: "Component not loaded",
+			items: []model.BundleItem{
+				{Kuantitas: 1},
+				{Kuantitas: 1, Component: component(10)},
+			},
+			expected: 0,
+		},
+		{name: "No components", expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			produk := model.Produk{Type: model.ProdukTypeBundle, Stok: 99, BundleItems: tt.items}
+
+			assert.Equal(t, tt.expected, bundleStock(produk))
+			assert.Equal(t, tt.expected, availableStock(produk))
+		})
+	}
+}
+
+func TestFillBundleStock(t *testing.T) {
+	produks := []model.Produk{
+		{Type: model.ProdukTypeSingle, Stok: 7},
+		{Type: model.ProdukTypeBundle, Stok: 99, BundleItems: []model.BundleItem{
+			{Kuantitas: 2, Component: &model.Produk{Stok: 9}},
+		}},
+	}
+
+	fillBundleStock(produks)
+
+	assert.Equal(t, 7, produks[0].Stok)
+	assert.Equal(t, 4, produks[1].Stok)
+}
@@ -11,6 +11,10 @@
 // - File ini berisi logic untuk CRUD kategori produk
 // - Kategori digunakan untuk mengklasifikasikan produk
 // - Hanya admin yang dapat mengelola kategori
+// - Kategori hierarkis: tree, breadcrumb, dan pemindahan tanpa siklus
+// - Kategori yang masih memiliki produk atau sub-kategori tidak dapat dihapus
+// - Skema atribut kategori diwariskan ke seluruh sub-kategori, nama atribut
+//   tidak boleh ganda dalam satu jalur tree termasuk saat kategori dipindah
 //
 // ============================================================================
 
@@ -20,9 +24,14 @@ import (
 	"errors"
 	"evermos-api/internal/model"
 	"evermos-api/internal/repository"
+	"fmt"
+	"regexp"
 	"time"
 )
 
+// attributeNamePattern keeps attribute names usable as attr.<name> query filters
+var attributeNamePattern = regexp.MustCompile(`^[a-z0-9_]{1,100}$`)
+
 // CategoryUsecase interface
 type CategoryUsecase interface {
 	GetAllCategory() ([]model.Category, error)
@@ -30,15 +39,22 @@ type CategoryUsecase interface {
 	CreateCategory(req model.CategoryRequest) (int, error)
 	UpdateCategory(id int, req model.CategoryRequest) error
 	DeleteCategory(id int) error
+	GetCategoryTree() ([]model.Category, error)
+	MoveCategory(id int, parentID *int) error
+	GetCategoryAttributes(id int) ([]model.CategoryAttribute, error)
+	CreateCategoryAttribute(id int, req model.CategoryAttributeRequest) (int, error)
+	UpdateCategoryAttribute(id, attributeID int, req model.CategoryAttributeRequest) error
+	DeleteCategoryAttribute(id, attributeID int) error
 }
 
 type categoryUsecase struct {
-	categoryRepo repository.CategoryRepository
+	categoryRepo  repository.CategoryRepository
+	attributeRepo repository.CategoryAttributeRepository
 }
 
 // NewCategoryUsecase creates new category usecase
-func NewCategoryUsecase(categoryRepo repository.CategoryRepository) CategoryUsecase {
-	return &categoryUsecase{categoryRepo: categoryRepo}
+func NewCategoryUsecase(categoryRepo repository.CategoryRepository, attributeRepo repository.CategoryAttributeRepository) CategoryUsecase {
+	return &categoryUsecase{categoryRepo: categoryRepo, attributeRepo: attributeRepo}
 }
 
 func (u *categoryUsecase) GetAllCategory() ([]model.Category, error) {
@@ -54,11 +70,19 @@ func (u *categoryUsecase) GetCategoryByID(id int) (*model.Category, error) {
 }
 
 func (u *categoryUsecase) CreateCategory(req model.CategoryRequest) (int, error) {
+	if req.ParentID != nil {
+		if _, err := u.categoryRepo.FindByID(*req.ParentID); err != nil {
+			return 0, errors.New("parent category not found")
+		}
+	}
+
 	now := time.Now()
 	category := &model.Category{
-		NamaCategory: req.NamaCategory,
-		CreatedAt:    &now,
-		UpdatedAt:    &now,
+		NamaCategory:     req.NamaCategory,
+		RequiresApproval: req.RequiresApproval,
+		IDParent:         req.ParentID,
+		CreatedAt:        &now,
+		UpdatedAt:        &now,
 	}
 
 	if err := u.categoryRepo.Create(category); err != nil {
@@ -74,7 +98,16 @@ func (u *categoryUsecase) UpdateCategory(id int, req model.CategoryRequest) erro
 		return errors.New("category not found")
 	}
 
+	// Omitted parent_id keeps the current parent, use MoveCategory to move to root
+	if req.ParentID != nil {
+		if err := u.checkParent(id, *req.ParentID); err != nil {
+			return err
+		}
+		category.IDParent = req.ParentID
+	}
+
 	category.NamaCategory = req.NamaCategory
+	category.RequiresApproval = req.RequiresApproval
 	now := time.Now()
 	category.UpdatedAt = &now
 
@@ -87,5 +120,286 @@ func (u *categoryUsecase) DeleteCategory(id int) error {
 		return errors.New("record not found")
 	}
 
+	children, err := u.categoryRepo.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.New("category still has sub-categories")
+	}
+
+	produks, err := u.categoryRepo.CountProduk(id)
+	if err != nil {
+		return err
+	}
+	if produks > 0 {
+		return errors.New("category still has products")
+	}
+
 	return u.categoryRepo.Delete(id)
 }
+
+// GetCategoryTree returns root categories with nested children
+func (u *categoryUsecase) GetCategoryTree() ([]model.Category, error) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return newCategoryIndex(categories).tree(), nil
+}
+
+// MoveCategory reparents a category, nil parentID moves it to the root
+func (u *categoryUsecase) MoveCategory(id int, parentID *int) error {
+	category, err := u.categoryRepo.FindByID(id)
+	if err != nil {
+		return errors.New("category not found")
+	}
+
+	if parentID != nil {
+		if err := u.checkParent(id, *parentID); err != nil {
+			return err
+		}
+	}
+
+	category.IDParent = parentID
+	now := time.Now()
+	category.UpdatedAt = &now
+
+	return u.categoryRepo.Update(category)
+}
+
+// GetCategoryAttributes returns the attribute schema of a category including
+// attributes inherited from its ancestors
+func (u *categoryUsecase) GetCategoryAttributes(id int) ([]model.CategoryAttribute, error) {
+	return effectiveAttributes(u.categoryRepo, u.attributeRepo, id)
+}
+
+func (u *categoryUsecase) CreateCategoryAttribute(id int, req model.CategoryAttributeRequest) (int, error) {
+	if err := validateAttributeRequest(req); err != nil {
+		return 0, err
+	}
+	if err := u.checkAttributeName(id, req.Name); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	attribute := &model.CategoryAttribute{
+		IDCategory: id,
+		Name:       req.Name,
+		Label:      req.Label,
+		Type:       req.Type,
+		Required:   req.Required,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}
+	if req.Type == model.AttributeTypeEnum {
+		attribute.AllowedValues = req.AllowedValues
+	}
+
+	if err := u.attributeRepo.Create(attribute); err != nil {
+		return 0, err
+	}
+
+	return attribute.ID, nil
+}
+
+// UpdateCategoryAttribute changes an attribute definition, the name is fixed
+// because stored product values refer to it
+func (u *categoryUsecase) UpdateCategoryAttribute(id, attributeID int, req model.CategoryAttributeRequest) error {
+	attribute, err := u.attributeRepo.FindByID(attributeID)
+	if err != nil || attribute.IDCategory != id {
+		return errors.New("attribute not found")
+	}
+	if req.Name != attribute.Name {
+		return errors.New("attribute name cannot be changed")
+	}
+	if err := validateAttributeRequest(req); err != nil {
+		return err
+	}
+
+	attribute.Label = req.Label
+	attribute.Type = req.Type
+	attribute.Required = req.Required
+	attribute.AllowedValues = nil
+	if req.Type == model.AttributeTypeEnum {
+		attribute.AllowedValues = req.AllowedValues
+	}
+	now := time.Now()
+	attribute.UpdatedAt = &now
+
+	return u.attributeRepo.Update(attribute)
+}
+
+func (u *categoryUsecase) DeleteCategoryAttribute(id, attributeID int) error {
+	attribute, err := u.attributeRepo.FindByID(attributeID)
+	if err != nil || attribute.IDCategory != id {
+		return errors.New("attribute not found")
+	}
+	return u.attributeRepo.Delete(attributeID)
+}
+
+// checkAttributeName makes sure the name is not already defined by the
+// category, its ancestors, or its descendants
+func (u *categoryUsecase) checkAttributeName(id int, name string) error {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	index := newCategoryIndex(categories)
+	if _, ok := index.byID[id]; !ok {
+		return errors.New("category not found")
+	}
+
+	ids := index.descendantIDs(id)
+	for _, crumb := range index.breadcrumb(id) {
+		if crumb.ID != id {
+			ids = append(ids, crumb.ID)
+		}
+	}
+
+	attributes, err := u.attributeRepo.FindByCategoryIDs(ids)
+	if err != nil {
+		return err
+	}
+	for _, attribute := range attributes {
+		if attribute.Name == name {
+			return errors.New("attribute already defined in this category tree")
+		}
+	}
+	return nil
+}
+
+func validateAttributeRequest(req model.CategoryAttributeRequest) error {
+	if !attributeNamePattern.MatchString(req.Name) {
+		return errors.New("attribute name may only contain lowercase letters, digits, and underscores")
+	}
+	if req.Type == model.AttributeTypeEnum && len(req.AllowedValues) == 0 {
+		return errors.New("enum attribute requires allowed_values")
+	}
+	return nil
+}
+
+// checkParent verifies parentID exists and is neither the category itself
+// nor one of its descendants, which would create a cycle, and that the moved
+// subtree defines no attribute name its new ancestors already define
+func (u *categoryUsecase) checkParent(id, parentID int) error {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return err
+	}
+
+	index := newCategoryIndex(categories)
+	if _, ok := index.byID[parentID]; !ok {
+		return errors.New("parent category not found")
+	}
+	for _, descendantID := range index.descendantIDs(id) {
+		if descendantID == parentID {
+			return errors.New("cannot move category into itself or its descendants")
+		}
+	}
+
+	subtree := make(map[int]bool)
+	ids := index.descendantIDs(id)
+	for _, subtreeID := range ids {
+		subtree[subtreeID] = true
+	}
+	for _, crumb := range index.breadcrumb(parentID) {
+		ids = append(ids, crumb.ID)
+	}
+
+	attributes, err := u.attributeRepo.FindByCategoryIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	inherited := make(map[string]bool)
+	for _, attribute := range attributes {
+		if !subtree[attribute.IDCategory] {
+			inherited[attribute.Name] = true
+		}
+	}
+	for _, attribute := range attributes {
+		if subtree[attribute.IDCategory] && inherited[attribute.Name] {
+			return fmt.Errorf("attribute %s is already defined by the new parent category tree", attribute.Name)
+		}
+	}
+	return nil
+}
+
+// categoryIndex indexes categories for tree traversal
+type categoryIndex struct {
+	byID     map[int]model.Category
+	children map[int][]int
+	roots    []int
+}
+
+func newCategoryIndex(categories []model.Category) *categoryIndex {
+	index := &categoryIndex{
+		byID:     make(map[int]model.Category, len(categories)),
+		children: make(map[int][]int),
+	}
+	for _, category := range categories {
+		index.byID[category.ID] = category
+	}
+	for _, category := range categories {
+		// Categories with a missing parent are treated as roots
+		if category.IDParent != nil {
+			if _, ok := index.byID[*category.IDParent]; ok {
+				index.children[*category.IDParent] = append(index.children[*category.IDParent], category.ID)
+				continue
+			}
+		}
+		index.roots = append(index.roots, category.ID)
+	}
+	return index
+}
+
+// descendantIDs returns id followed by the IDs of all its descendants
+func (idx *categoryIndex) descendantIDs(id int) []int {
+	ids := []int{id}
+	seen := map[int]bool{id: true}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range idx.children[ids[i]] {
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+			}
+		}
+	}
+	return ids
+}
+
+// breadcrumb returns the path from the root category down to id
+func (idx *categoryIndex) breadcrumb(id int) []model.CategoryBreadcrumb {
+	var path []model.CategoryBreadcrumb
+	seen := make(map[int]bool)
+	for current, ok := idx.byID[id]; ok && !seen[current.ID]; current, ok = idx.parent(current) {
+		seen[current.ID] = true
+		path = append([]model.CategoryBreadcrumb{{ID: current.ID, NamaCategory: current.NamaCategory}}, path...)
+	}
+	return path
+}
+
+func (idx *categoryIndex) parent(category model.Category) (model.Category, bool) {
+	if category.IDParent == nil {
+		return model.Category{}, false
+	}
+	parent, ok := idx.byID[*category.IDParent]
+	return parent, ok
+}
+
+// tree builds nested categories starting from the roots
+func (idx *categoryIndex) tree() []model.Category {
+	var build func(ids []int) []model.Category
+	build = func(ids []int) []model.Category {
+		nodes := make([]model.Category, 0, len(ids))
+		for _, id := range ids {
+			node := idx.byID[id]
+			node.Children = build(idx.children[id])
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(idx.roots)
+}
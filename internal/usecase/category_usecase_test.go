@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCategoryRepository is a mock implementation of CategoryRepository
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) Create(category *model.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) FindByID(id int) (*model.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindAll() ([]model.Category, error) {
+	args := m.Called()
+	return args.Get(0).([]model.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Update(category *model.Category) error {
+	args := m.Called(category)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) CountChildren(id int) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCategoryRepository) CountProduk(id int) (int64, error) {
+	args := m.Called(id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockCategoryAttributeRepository is a mock implementation of CategoryAttributeRepository
+type MockCategoryAttributeRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryAttributeRepository) Create(attribute *model.CategoryAttribute) error {
+	args := m.Called(attribute)
+	return args.Error(0)
+}
+
+func (m *MockCategoryAttributeRepository) FindByID(id int) (*model.CategoryAttribute, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CategoryAttribute), args.Error(1)
+}
+
+func (m *MockCategoryAttributeRepository) FindByCategoryIDs(categoryIDs []int) ([]model.CategoryAttribute, error) {
+	args := m.Called(categoryIDs)
+	// A function return computes the attributes from the requested IDs
+	if fn, ok := args.Get(0).(func([]int) []model.CategoryAttribute); ok {
+		return fn(categoryIDs), args.Error(1)
+	}
+	return args.Get(0).([]model.CategoryAttribute), args.Error(1)
+}
+
+func (m *MockCategoryAttributeRepository) Update(attribute *model.CategoryAttribute) error {
+	args := m.Called(attribute)
+	return args.Error(0)
+}
+
+func (m *MockCategoryAttributeRepository) Delete(id int) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func intPtr(v int) *int {
+	return &v
+}
+
+// testCategories is the tree
+//
+//	1 Fashion
+//	├── 2 Pria
+//	│   └── 4 Kemeja
+//	└── 3 Wanita
+//	5 Elektronik
+//
+// with attributes "bahan" on Fashion, "ukuran" on Kemeja and Elektronik,
+// and "garansi" on Elektronik
+func testCategories() []model.Category {
+	return []model.Category{
+		{ID: 1, NamaCategory: "Fashion"},
+		{ID: 2, NamaCategory: "Pria", IDParent: intPtr(1)},
+		{ID: 3, NamaCategory: "Wanita", IDParent: intPtr(1)},
+		{ID: 4, NamaCategory: "Kemeja", IDParent: intPtr(2)},
+		{ID: 5, NamaCategory: "Elektronik"},
+	}
+}
+
+func testAttributes() []model.CategoryAttribute {
+	return []model.CategoryAttribute{
+		{ID: 1, IDCategory: 1, Name: "bahan"},
+		{ID: 2, IDCategory: 4, Name: "ukuran"},
+		{ID: 3, IDCategory: 5, Name: "ukuran"},
+		{ID: 4, IDCategory: 5, Name: "garansi"},
+	}
+}
+
+// attributesOf returns the test attributes of the given categories
+func attributesOf(ids []int) []model.CategoryAttribute {
+	var attributes []model.CategoryAttribute
+	for _, attribute := range testAttributes() {
+		for _, id := range ids {
+			if attribute.IDCategory == id {
+				attributes = append(attributes, attribute)
+			}
+		}
+	}
+	return attributes
+}
+
+func TestCategoryUsecase_CheckParent(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       int
+		parentID int
+		wantErr  string
+	}{
+		{"Move to sibling", 4, 3, ""},
+		{"Move without attributes", 3, 5, ""},
+		{"Move root under leaf with same name", 5, 4, "attribute ukuran is already defined by the new parent category tree"},
+		{"Move to other root with conflict", 2, 5, "attribute ukuran is already defined by the new parent category tree"},
+		{"Move into tree defining same name", 4, 5, "attribute ukuran is already defined by the new parent category tree"},
+		{"Move root under other tree", 5, 3, ""},
+		{"Move into itself", 2, 2, "cannot move category into itself or its descendants"},
+		{"Move into child", 1, 2, "cannot move category into itself or its descendants"},
+		{"Move into grandchild", 1, 4, "cannot move category into itself or its descendants"},
+		{"Missing parent", 2, 99, "parent category not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCategoryRepo := new(MockCategoryRepository)
+			mockCategoryRepo.On("FindAll").Return(testCategories(), nil)
+			mockAttributeRepo := new(MockCategoryAttributeRepository)
+			mockAttributeRepo.On("FindByCategoryIDs", mock.Anything).Return(attributesOf, nil).Maybe()
+			categoryUsecase := &categoryUsecase{categoryRepo: mockCategoryRepo, attributeRepo: mockAttributeRepo}
+
+			err := categoryUsecase.checkParent(tt.id, tt.parentID)
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+			mockCategoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCategoryIndex_DescendantIDs(t *testing.T) {
+	index := newCategoryIndex(testCategories())
+
+	assert.Equal(t, []int{1, 2, 3, 4}, index.descendantIDs(1))
+	assert.Equal(t, []int{2, 4}, index.descendantIDs(2))
+	assert.Equal(t, []int{4}, index.descendantIDs(4))
+	assert.Equal(t, []int{99}, index.descendantIDs(99))
+}
+
+func TestCategoryIndex_Breadcrumb(t *testing.T) {
+	index := newCategoryIndex(testCategories())
+
+	assert.Equal(t, []model.CategoryBreadcrumb{
+		{ID: 1, NamaCategory: "Fashion"},
+		{ID: 2, NamaCategory: "Pria"},
+		{ID: 4, NamaCategory: "Kemeja"},
+	}, index.breadcrumb(4))
+	assert.Equal(t, []model.CategoryBreadcrumb{{ID: 5, NamaCategory: "Elektronik"}}, index.breadcrumb(5))
+	assert.Empty(t, index.breadcrumb(99))
+}
+
+func TestCategoryIndex_CorruptCycle(t *testing.T) {
+	// 1 -> 2 -> 3 -> 1 can only exist through data edited outside the API
+	index := newCategoryIndex([]model.Category{
+		{ID: 1, NamaCategory: "A", IDParent: intPtr(3)},
+		{ID: 2, NamaCategory: "B", IDParent: intPtr(1)},
+		{ID: 3, NamaCategory: "C", IDParent: intPtr(2)},
+		{ID: 4, NamaCategory: "D"},
+	})
+
+	assert.Equal(t, []model.CategoryBreadcrumb{
+		{ID: 2, NamaCategory: "B"},
+		{ID: 3, NamaCategory: "C"},
+		{ID: 1, NamaCategory: "A"},
+	}, index.breadcrumb(1))
+	assert.ElementsMatch(t, []int{1, 2, 3}, index.descendantIDs(1))
+
+	// Cycle members have no root, the tree only holds the healthy category
+	tree := index.tree()
+	assert.Len(t, tree, 1)
+	assert.Equal(t, 4, tree[0].ID)
+}
+
+func TestCategoryIndex_MissingParentIsRoot(t *testing.T) {
+	index := newCategoryIndex([]model.Category{
+		{ID: 1, NamaCategory: "Orphan", IDParent: intPtr(42)},
+		{ID: 2, NamaCategory: "Child", IDParent: intPtr(1)},
+	})
+
+	tree := index.tree()
+	assert.Len(t, tree, 1)
+	assert.Equal(t, 1, tree[0].ID)
+	assert.Len(t, tree[0].Children, 1)
+	assert.Equal(t, []model.CategoryBreadcrumb{
+		{ID: 1, NamaCategory: "Orphan"},
+		{ID: 2, NamaCategory: "Child"},
+	}, index.breadcrumb(2))
+}
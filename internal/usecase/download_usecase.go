@@ -0,0 +1,254 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : download_usecase.go
+// Description  : Business logic untuk produk digital dan unduhan pembeli
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic daftar hak unduh transaksi dan pembuatan link unduh
+// - Hak unduh dibuat belum aktif saat transaksi produk digital dibuat dan
+//   diaktifkan saat transaksi dibayar, berlaku download_days hari sejak
+//   dibayar untuk download_limit unduhan
+// - Link unduh ditolak selama transaksi belum dibayar
+// - Link unduh adalah signed URL media privat yang berlaku singkat, setiap link
+//   yang dibuat mengurangi sisa unduhan
+// - File digital lama hanya dihapus jika tidak ada hak unduh aktif atau
+//   menunggu pembayaran (maksimal 3 hari) yang memakainya, file yang masih
+//   dipakai dicatat dan dihapus belakangan oleh job purge
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"evermos-api/internal/storage"
+	"evermos-api/internal/utils"
+	"mime/multipart"
+	"path/filepath"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultDownloadLimit = 5
+	defaultDownloadDays  = 7
+	// Unpaid transactions with digital products must be paid within this
+	// window, later their grants no longer keep replaced files in storage
+	digitalPaymentWindow = 3 * 24 * time.Hour
+)
+
+// DownloadUsecase interface
+type DownloadUsecase interface {
+	GetTrxDownloads(trxID, userID int) ([]model.DownloadGrant, error)
+	CreateDownloadLink(trxID, grantID, userID int) (*model.DownloadLink, error)
+}
+
+type downloadUsecase struct {
+	grantRepo repository.DownloadGrantRepository
+	trxRepo   repository.TrxRepository
+}
+
+// NewDownloadUsecase creates new download usecase
+func NewDownloadUsecase(
+	grantRepo repository.DownloadGrantRepository,
+	trxRepo repository.TrxRepository,
+) DownloadUsecase {
+	return &downloadUsecase{
+		grantRepo: grantRepo,
+		trxRepo:   trxRepo,
+	}
+}
+
+func (u *downloadUsecase) GetTrxDownloads(trxID, userID int) ([]model.DownloadGrant, error) {
+	trx, err := u.trxRepo.FindByID(trxID)
+	if err != nil {
+		return nil, errors.New("`No Data Trx`")
+	}
+	if trx.IDUser != userID {
+		return nil, errors.New("unauthorized: not your transaction")
+	}
+
+	return u.grantRepo.FindByTrxID(trxID)
+}
+
+// CreateDownloadLink uses one download of a grant and returns a short-lived
+// signed URL to the file
+func (u *downloadUsecase) CreateDownloadLink(trxID, grantID, userID int) (*model.DownloadLink, error) {
+	grant, err := u.grantRepo.FindByID(grantID)
+	if err != nil || grant.IDTrx != trxID {
+		return nil, errors.New("download not found")
+	}
+	if grant.IDUser != userID {
+		return nil, errors.New("unauthorized: not your transaction")
+	}
+
+	trx, err := u.trxRepo.FindByID(trxID)
+	if err != nil {
+		return nil, errors.New("`No Data Trx`")
+	}
+	if trx.StatusBayar != model.TrxStatusPaid || grant.ExpiresAt == nil {
+		return nil, errors.New("transaction has not been paid")
+	}
+
+	now := time.Now()
+	if !grant.ExpiresAt.After(now) {
+		return nil, errors.New("download has expired")
+	}
+
+	claimed, err := u.grantRepo.Claim(grant.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.New("download limit reached")
+	}
+
+	// The link never outlives the grant itself
+	ttl := utils.Media.SignedURLTTL
+	if remaining := grant.ExpiresAt.Sub(now); remaining < ttl {
+		ttl = remaining
+	}
+
+	return &model.DownloadLink{
+		URL:                utils.SignedMediaURL(grant.FileKey, ttl),
+		FileName:           grant.FileName,
+		ExpiresAt:          now.Add(ttl),
+		RemainingDownloads: grant.MaxDownloads - grant.Downloads - 1,
+	}, nil
+}
+
+// uploadDigitalFile stores the file of a digital product and sets it on produk
+func uploadDigitalFile(produk *model.Produk, file *multipart.FileHeader, store storage.Storage) error {
+	key, err := utils.UploadDigitalFile(file, store, "digital")
+	if err != nil {
+		return err
+	}
+	produk.DigitalFile = key
+	produk.DigitalFileName = filepath.Base(file.Filename)
+	return nil
+}
+
+// validateDigital checks the digital file and download settings of a product
+// before file is uploaded, filling the defaults of a digital product
+func validateDigital(produk *model.Produk, file *multipart.FileHeader) error {
+	if produk.Type != model.ProdukTypeDigital {
+		if file != nil {
+			return errors.New("digital_file is only allowed for digital products")
+		}
+		return nil
+	}
+	if produk.DigitalFile == "" && file == nil {
+		return errors.New("digital_file is required for digital products")
+	}
+	if produk.DownloadLimit <= 0 {
+		produk.DownloadLimit = defaultDownloadLimit
+	}
+	if produk.DownloadDays <= 0 {
+		produk.DownloadDays = defaultDownloadDays
+	}
+	return nil
+}
+
+// grantDownload records the file of a digital detail_trx for its buyer inside
+// tx, the grant keeps the file as sold and stays inactive until the trx is paid
+func grantDownload(tx *gorm.DB, trx *model.Trx, detail *model.DetailTrx, produk *model.Produk, now time.Time) error {
+	if produk.Type != model.ProdukTypeDigital {
+		return nil
+	}
+
+	grant := &model.DownloadGrant{
+		IDTrx:        trx.ID,
+		IDDetailTrx:  detail.ID,
+		IDUser:       trx.IDUser,
+		IDProduk:     produk.ID,
+		FileKey:      produk.DigitalFile,
+		FileName:     produk.DigitalFileName,
+		MaxDownloads: produk.DownloadLimit * detail.Kuantitas,
+		ValidDays:    produk.DownloadDays,
+		CreatedAt:    &now,
+	}
+	return tx.Create(grant).Error
+}
+
+// activateDownloads starts the download period of the grants of a paid trx
+// inside tx, grants left unpaid past the payment window are refused since
+// their file may already be removed
+func activateDownloads(tx *gorm.DB, trxID int, now time.Time) error {
+	var stale int64
+	err := tx.Model(&model.DownloadGrant{}).
+		Where("id_trx = ? AND activated_at IS NULL AND created_at <= ?", trxID, now.Add(-digitalPaymentWindow)).
+		Count(&stale).Error
+	if err != nil {
+		return err
+	}
+	if stale > 0 {
+		return errors.New("payment window for digital products has expired")
+	}
+
+	return tx.Exec(
+		"UPDATE download_grant SET activated_at = ?, expires_at = DATE_ADD(?, INTERVAL valid_days DAY) WHERE id_trx = ? AND activated_at IS NULL",
+		now, now, trxID,
+	).Error
+}
+
+// digitalFileInUse reports whether buyers can still download a file, grants
+// waiting for payment keep it too until the payment window has passed
+func digitalFileInUse(db *gorm.DB, fileKey string, now time.Time) (bool, error) {
+	var count int64
+	err := db.Model(&model.DownloadGrant{}).
+		Where("file_key = ? AND downloads < max_downloads", fileKey).
+		Where("((activated_at IS NULL AND created_at > ?) OR expires_at > ?)", now.Add(-digitalPaymentWindow), now).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// releaseDigitalFile reports whether a digital file no product sells anymore
+// can be removed now. A file buyers can still download is recorded inside tx
+// instead, sweepDigitalFiles removes it once its grants are used up.
+func releaseDigitalFile(tx *gorm.DB, fileKey string, now time.Time) (bool, error) {
+	inUse, err := digitalFileInUse(tx, fileKey, now)
+	if err != nil {
+		return false, err
+	}
+	if !inUse {
+		return true, nil
+	}
+
+	retained := &model.RetainedDigitalFile{FileKey: fileKey, CreatedAt: &now}
+	return false, tx.Clauses(clause.OnConflict{DoNothing: true}).Create(retained).Error
+}
+
+// sweepDigitalFiles removes the retained digital files no grant uses anymore,
+// returning how many were removed
+func sweepDigitalFiles(db *gorm.DB, store storage.Storage, now time.Time) (int, error) {
+	var files []model.RetainedDigitalFile
+	if err := db.Order("id ASC").Find(&files).Error; err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		inUse, err := digitalFileInUse(db, file.FileKey, now)
+		if err != nil {
+			return removed, err
+		}
+		if inUse {
+			continue
+		}
+
+		if err := db.Delete(&model.RetainedDigitalFile{}, file.ID).Error; err != nil {
+			return removed, err
+		}
+		removeFiles(store, []string{file.FileKey})
+		removed++
+	}
+	return removed, nil
+}
@@ -0,0 +1,263 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : moderation_usecase.go
+// Description  : Business logic untuk moderasi produk oleh admin
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic antrian moderasi, approve, dan reject produk
+// - Produk perlu approval jika kategorinya mewajibkan atau trust level toko
+//   di bawah batas minimum
+// - Kata terlarang dicek pada nama dan deskripsi produk, regexp-nya dikompilasi
+//   sekali saat daftar dimuat dan dimuat ulang setelah kata ditambah/dihapus
+//   atau setelah KeywordCacheTTL (perubahan dari instance lain)
+// - Penjual mendapat notifikasi hasil moderasi
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ModerationUsecase interface
+type ModerationUsecase interface {
+	CheckContent(texts ...string) error
+	RequiresApproval(toko *model.Toko, categoryID int) bool
+	GetModerationQueue(status string, limit, offset int) (*model.PaginatedResponse, error)
+	ApproveProduk(id int) error
+	RejectProduk(id int, reason string) error
+	GetBannedKeywords() ([]model.BannedKeyword, error)
+	CreateBannedKeyword(req model.BannedKeywordRequest) (int, error)
+	DeleteBannedKeyword(id int) error
+	SetTokoTrustLevel(tokoID, trustLevel int) error
+}
+
+type moderationUsecase struct {
+	produkRepo        repository.ProdukRepository
+	tokoRepo          repository.TokoRepository
+	categoryRepo      repository.CategoryRepository
+	bannedKeywordRepo repository.BannedKeywordRepository
+	minTrustLevel     int
+	keywordCacheTTL   time.Duration
+	db                *gorm.DB
+
+	mu                sync.RWMutex
+	bannedPatterns    []bannedPattern
+	patternsExpiresAt time.Time
+	patternsGen       int
+}
+
+// bannedPattern is a banned keyword with its compiled whole-word regexp
+type bannedPattern struct {
+	keyword string
+	re      *regexp.Regexp
+}
+
+// NewModerationUsecase creates new moderation usecase
+func NewModerationUsecase(
+	produkRepo repository.ProdukRepository,
+	tokoRepo repository.TokoRepository,
+	categoryRepo repository.CategoryRepository,
+	bannedKeywordRepo repository.BannedKeywordRepository,
+	minTrustLevel int,
+	keywordCacheTTL time.Duration,
+	db *gorm.DB,
+) ModerationUsecase {
+	return &moderationUsecase{
+		produkRepo:        produkRepo,
+		tokoRepo:          tokoRepo,
+		categoryRepo:      categoryRepo,
+		bannedKeywordRepo: bannedKeywordRepo,
+		minTrustLevel:     minTrustLevel,
+		keywordCacheTTL:   keywordCacheTTL,
+		db:                db,
+	}
+}
+
+// CheckContent rejects texts containing a banned keyword as a whole word
+func (u *moderationUsecase) CheckContent(texts ...string) error {
+	patterns, err := u.loadBannedPatterns()
+	if err != nil {
+		return errors.New("failed to check banned keywords")
+	}
+
+	content := strings.ToLower(strings.Join(texts, "\n"))
+	for _, pattern := range patterns {
+		if pattern.re.MatchString(content) {
+			return fmt.Errorf("product contains banned keyword: %s", pattern.keyword)
+		}
+	}
+	return nil
+}
+
+// loadBannedPatterns returns the compiled banned keywords, reloading them
+// after a change to the list or once the cache TTL has passed, since other
+// instances may have changed the list
+func (u *moderationUsecase) loadBannedPatterns() ([]bannedPattern, error) {
+	u.mu.RLock()
+	patterns, gen, expiresAt := u.bannedPatterns, u.patternsGen, u.patternsExpiresAt
+	u.mu.RUnlock()
+	if patterns != nil && time.Now().Before(expiresAt) {
+		return patterns, nil
+	}
+
+	keywords, err := u.bannedKeywordRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	patterns = make([]bannedPattern, 0, len(keywords))
+	for _, keyword := range keywords {
+		pattern := `(^|[^\p{L}\p{N}])` + regexp.QuoteMeta(strings.ToLower(keyword.Keyword)) + `($|[^\p{L}\p{N}])`
+		patterns = append(patterns, bannedPattern{keyword: keyword.Keyword, re: regexp.MustCompile(pattern)})
+	}
+
+	// A list changed while loading is left for the next check to load
+	u.mu.Lock()
+	if u.patternsGen == gen {
+		u.bannedPatterns = patterns
+		u.patternsExpiresAt = time.Now().Add(u.keywordCacheTTL)
+	}
+	u.mu.Unlock()
+	return patterns, nil
+}
+
+// resetBannedPatterns drops the compiled keywords after the list changed
+func (u *moderationUsecase) resetBannedPatterns() {
+	u.mu.Lock()
+	u.bannedPatterns = nil
+	u.patternsGen++
+	u.mu.Unlock()
+}
+
+// RequiresApproval reports whether products of this toko in this category
+// must be approved by an admin before they are visible
+func (u *moderationUsecase) RequiresApproval(toko *model.Toko, categoryID int) bool {
+	if toko.TrustLevel < u.minTrustLevel {
+		return true
+	}
+
+	category, err := u.categoryRepo.FindByID(categoryID)
+	return err == nil && category.RequiresApproval
+}
+
+func (u *moderationUsecase) GetModerationQueue(status string, limit, offset int) (*model.PaginatedResponse, error) {
+	if status == "" {
+		status = model.ModerationPending
+	}
+
+	produks, err := u.produkRepo.FindAll(limit, offset, map[string]interface{}{
+		"moderation_status":   status,
+		"include_unpublished": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  produks,
+	}, nil
+}
+
+func (u *moderationUsecase) ApproveProduk(id int) error {
+	return u.moderate(id, model.ModerationApproved, "")
+}
+
+func (u *moderationUsecase) RejectProduk(id int, reason string) error {
+	return u.moderate(id, model.ModerationRejected, reason)
+}
+
+// moderate stores the moderation decision and notifies the seller
+func (u *moderationUsecase) moderate(id int, status, reason string) error {
+	produk, err := u.produkRepo.FindByID(id)
+	if err != nil {
+		return errors.New("product not found")
+	}
+
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return errors.New("toko not found")
+	}
+
+	// Only the moderation columns are written, a full save would restore
+	// the stock read above over concurrent checkouts
+	now := time.Now()
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.Produk{}).Where("id = ?", produk.ID).Updates(map[string]interface{}{
+			"moderation_status": status,
+			"moderation_note":   reason,
+			"updated_at":        now,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		if status == model.ModerationApproved {
+			return notify(tx, toko.IDUser, model.NotificationProdukApproved,
+				"Produk disetujui",
+				fmt.Sprintf("Produk \"%s\" telah disetujui dan dapat tampil di katalog.", produk.NamaProduk),
+				produk.ID)
+		}
+		return notify(tx, toko.IDUser, model.NotificationProdukRejected,
+			"Produk ditolak",
+			fmt.Sprintf("Produk \"%s\" ditolak: %s", produk.NamaProduk, reason),
+			produk.ID)
+	})
+}
+
+func (u *moderationUsecase) GetBannedKeywords() ([]model.BannedKeyword, error) {
+	return u.bannedKeywordRepo.FindAll()
+}
+
+func (u *moderationUsecase) CreateBannedKeyword(req model.BannedKeywordRequest) (int, error) {
+	keyword := strings.TrimSpace(req.Keyword)
+	if keyword == "" {
+		return 0, errors.New("keyword is required")
+	}
+
+	now := time.Now()
+	bannedKeyword := &model.BannedKeyword{
+		Keyword:   keyword,
+		CreatedAt: &now,
+	}
+	if err := u.bannedKeywordRepo.Create(bannedKeyword); err != nil {
+		return 0, errors.New("keyword already exists")
+	}
+	u.resetBannedPatterns()
+	return bannedKeyword.ID, nil
+}
+
+func (u *moderationUsecase) DeleteBannedKeyword(id int) error {
+	if err := u.bannedKeywordRepo.Delete(id); err != nil {
+		return errors.New("keyword not found")
+	}
+	u.resetBannedPatterns()
+	return nil
+}
+
+func (u *moderationUsecase) SetTokoTrustLevel(tokoID, trustLevel int) error {
+	if _, err := u.tokoRepo.FindByID(tokoID); err != nil {
+		return errors.New("toko not found")
+	}
+
+	return u.db.Model(&model.Toko{}).Where("id = ?", tokoID).Updates(map[string]interface{}{
+		"trust_level": trustLevel,
+		"updated_at":  time.Now(),
+	}).Error
+}
@@ -0,0 +1,111 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : notification_usecase.go
+// Description  : Business logic untuk notifikasi in-app
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic untuk membaca dan menandai notifikasi
+// - Notifikasi dibuat oleh usecase lain melalui helper notify, atau notifyAll
+//   untuk banyak user sekaligus dengan batch insert
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationUsecase interface
+type NotificationUsecase interface {
+	GetMyNotifications(userID int, limit, offset int) (*model.PaginatedResponse, error)
+	MarkAsRead(id, userID int) error
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+// NewNotificationUsecase creates new notification usecase
+func NewNotificationUsecase(notificationRepo repository.NotificationRepository) NotificationUsecase {
+	return &notificationUsecase{notificationRepo: notificationRepo}
+}
+
+func (u *notificationUsecase) GetMyNotifications(userID int, limit, offset int) (*model.PaginatedResponse, error) {
+	notifications, err := u.notificationRepo.FindByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  notifications,
+	}, nil
+}
+
+func (u *notificationUsecase) MarkAsRead(id, userID int) error {
+	notification, err := u.notificationRepo.FindByID(id)
+	if err != nil {
+		return errors.New("notification not found")
+	}
+
+	// Check ownership
+	if notification.IDUser != userID {
+		return errors.New("unauthorized: not your notification")
+	}
+
+	if notification.IsRead {
+		return nil
+	}
+
+	notification.IsRead = true
+	return u.notificationRepo.Update(notification)
+}
+
+// notify stores a notification for userID within the caller's transaction
+func notify(tx *gorm.DB, userID int, notificationType, title, message string, refID int) error {
+	now := time.Now()
+	return tx.Create(&model.Notification{
+		IDUser:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		IDRef:     refID,
+		CreatedAt: &now,
+	}).Error
+}
+
+// notifyBatchSize is the number of notifications inserted per statement
+const notifyBatchSize = 500
+
+// notifyAll stores the same notification for every user in userIDs within
+// the caller's transaction, in batched inserts
+func notifyAll(tx *gorm.DB, userIDs []int, notificationType, title, message string, refID int) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	notifications := make([]model.Notification, 0, len(userIDs))
+	for _, userID := range userIDs {
+		notifications = append(notifications, model.Notification{
+			IDUser:    userID,
+			Type:      notificationType,
+			Title:     title,
+			Message:   message,
+			IDRef:     refID,
+			CreatedAt: &now,
+		})
+	}
+	return tx.CreateInBatches(&notifications, notifyBatchSize).Error
+}
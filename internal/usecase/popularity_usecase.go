@@ -0,0 +1,186 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : popularity_usecase.go
+// Description  : Business logic untuk tracking view dan popularitas produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi pencatatan view detail produk, skor popularitas, dan
+//   daftar produk trending
+// - View dideduplikasi per user/sesi dan dibatasi per menit di memori, lalu
+//   ditulis ke database secara berkala (FlushViews) agar request tidak tertunda
+// - Skor popularitas = view + penjualan x popularitySaleWeight dalam periode
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"sync"
+	"time"
+)
+
+const (
+	// popularitySaleWeight is how many views a sold unit is worth
+	popularitySaleWeight = 20
+	// maxViewsPerMinute caps the views counted for one viewer
+	maxViewsPerMinute = 30
+
+	defaultTrendingDays  = 7
+	maxTrendingDays      = 30
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 50
+)
+
+// PopularityUsecase interface
+type PopularityUsecase interface {
+	RecordView(produkID int, viewer string)
+	FlushViews() error
+	RefreshScores() (int64, error)
+	GetTrending(days, limit int) ([]model.Produk, error)
+}
+
+type viewKey struct {
+	produkID int
+	viewer   string
+}
+
+type pendingViewKey struct {
+	produkID int
+	date     time.Time
+}
+
+type viewerRate struct {
+	start time.Time
+	count int
+}
+
+type popularityUsecase struct {
+	popularityRepo repository.PopularityRepository
+	promoRepo      repository.ProdukPromoRepository
+	windowDays     int
+	dedupeWindow   time.Duration
+
+	mu      sync.Mutex
+	seen    map[viewKey]time.Time
+	rates   map[string]*viewerRate
+	pending map[pendingViewKey]int
+}
+
+// NewPopularityUsecase creates new popularity usecase
+func NewPopularityUsecase(
+	popularityRepo repository.PopularityRepository,
+	promoRepo repository.ProdukPromoRepository,
+	windowDays int,
+	dedupeWindow time.Duration,
+) PopularityUsecase {
+	return &popularityUsecase{
+		popularityRepo: popularityRepo,
+		promoRepo:      promoRepo,
+		windowDays:     windowDays,
+		dedupeWindow:   dedupeWindow,
+		seen:           make(map[viewKey]time.Time),
+		rates:          make(map[string]*viewerRate),
+		pending:        make(map[pendingViewKey]int),
+	}
+}
+
+// RecordView counts a product detail view in memory, it never touches the
+// database so it can be called from the request path
+func (u *popularityUsecase) RecordView(produkID int, viewer string) {
+	now := time.Now()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	key := viewKey{produkID: produkID, viewer: viewer}
+	if last, ok := u.seen[key]; ok && now.Sub(last) < u.dedupeWindow {
+		return
+	}
+
+	rate := u.rates[viewer]
+	if rate == nil || now.Sub(rate.start) >= time.Minute {
+		rate = &viewerRate{start: now}
+		u.rates[viewer] = rate
+	}
+	if rate.count >= maxViewsPerMinute {
+		return
+	}
+	rate.count++
+
+	u.seen[key] = now
+	u.pending[pendingViewKey{produkID: produkID, date: truncateDay(now)}]++
+}
+
+// FlushViews writes the buffered views to the daily aggregates
+func (u *popularityUsecase) FlushViews() error {
+	now := time.Now()
+
+	u.mu.Lock()
+	pending := u.pending
+	u.pending = make(map[pendingViewKey]int)
+	// Forget viewers that can no longer be deduplicated or throttled
+	for key, last := range u.seen {
+		if now.Sub(last) >= u.dedupeWindow {
+			delete(u.seen, key)
+		}
+	}
+	for viewer, rate := range u.rates {
+		if now.Sub(rate.start) >= time.Minute {
+			delete(u.rates, viewer)
+		}
+	}
+	u.mu.Unlock()
+
+	views := make([]model.ProdukViewDaily, 0, len(pending))
+	for key, count := range pending {
+		views = append(views, model.ProdukViewDaily{IDProduk: key.produkID, Date: key.date, Views: count})
+	}
+	if err := u.popularityRepo.AddViews(views); err != nil {
+		// Keep the views for the next flush
+		u.mu.Lock()
+		for key, count := range pending {
+			u.pending[key] += count
+		}
+		u.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// RefreshScores recomputes the popularity score of all products
+func (u *popularityUsecase) RefreshScores() (int64, error) {
+	since := truncateDay(time.Now()).AddDate(0, 0, -u.windowDays)
+	return u.popularityRepo.RefreshScores(since, popularitySaleWeight)
+}
+
+// GetTrending returns the most viewed and bought products of the last days
+func (u *popularityUsecase) GetTrending(days, limit int) ([]model.Produk, error) {
+	if days <= 0 {
+		days = defaultTrendingDays
+	}
+	if days > maxTrendingDays {
+		days = maxTrendingDays
+	}
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	since := truncateDay(time.Now()).AddDate(0, 0, -days)
+	produks, err := u.popularityRepo.FindTrending(since, popularitySaleWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	fillPromos(u.promoRepo, produks, time.Now())
+	fillBundleStock(produks)
+	return produks, nil
+}
@@ -0,0 +1,139 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : preorder_usecase.go
+// Description  : Business logic untuk produk pre-order
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic daftar pre-order toko dan penandaan pengiriman
+// - Estimasi kirim (ship_by) = tanggal transaksi + lead time produk
+// - Pre-order yang belum dikirim setelah ship_by dianggap terlambat (overdue)
+// - Quota pre-order diklaim secara atomik di dalam transaksi checkout
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const maxPreorderLeadDays = 365
+
+// PreorderUsecase interface
+type PreorderUsecase interface {
+	GetMyPreorders(userID int, overdue bool, limit, offset int) (*model.PaginatedResponse, error)
+	MarkShipped(detailID, userID int) error
+}
+
+type preorderUsecase struct {
+	detailTrxRepo repository.DetailTrxRepository
+	tokoRepo      repository.TokoRepository
+}
+
+// NewPreorderUsecase creates new preorder usecase
+func NewPreorderUsecase(
+	detailTrxRepo repository.DetailTrxRepository,
+	tokoRepo repository.TokoRepository,
+) PreorderUsecase {
+	return &preorderUsecase{
+		detailTrxRepo: detailTrxRepo,
+		tokoRepo:      tokoRepo,
+	}
+}
+
+func (u *preorderUsecase) GetMyPreorders(userID int, overdue bool, limit, offset int) (*model.PaginatedResponse, error) {
+	toko, err := u.tokoRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("toko not found")
+	}
+
+	var overdueBefore *time.Time
+	if overdue {
+		today := truncateDay(time.Now())
+		overdueBefore = &today
+	}
+
+	details, err := u.detailTrxRepo.FindPreordersByTokoID(toko.ID, overdueBefore, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  details,
+	}, nil
+}
+
+func (u *preorderUsecase) MarkShipped(detailID, userID int) error {
+	detail, err := u.detailTrxRepo.FindByIDWithRelations(detailID)
+	if err != nil || detail.ShipBy == nil {
+		return errors.New("pre-order not found")
+	}
+
+	// Check ownership
+	toko, err := u.tokoRepo.FindByUserID(userID)
+	if err != nil || detail.IDToko != toko.ID {
+		return errors.New("unauthorized: not your order")
+	}
+
+	if detail.ShippedAt != nil {
+		return errors.New("pre-order already shipped")
+	}
+
+	now := time.Now()
+	detail.ShippedAt = &now
+	detail.UpdatedAt = &now
+	return u.detailTrxRepo.Update(detail)
+}
+
+// validatePreorder checks the pre-order settings of a product
+func validatePreorder(produk *model.Produk) error {
+	if !produk.IsPreorder {
+		return nil
+	}
+	if produk.Type == model.ProdukTypeBundle {
+		return errors.New("pre-order is not available for bundle products")
+	}
+	if produk.Type == model.ProdukTypeDigital {
+		return errors.New("pre-order is not available for digital products")
+	}
+	if produk.PreorderLeadDays < 1 || produk.PreorderLeadDays > maxPreorderLeadDays {
+		return fmt.Errorf("preorder_lead_days must be between 1 and %d for pre-order products", maxPreorderLeadDays)
+	}
+	if produk.PreorderQuota > 0 && produk.PreorderQuota < produk.PreorderSold {
+		return fmt.Errorf("preorder_quota cannot be below the %d pre-orders already sold", produk.PreorderSold)
+	}
+	return nil
+}
+
+// preorderShipBy is the estimated ship date of a pre-order placed at now
+func preorderShipBy(produk *model.Produk, now time.Time) *time.Time {
+	if !produk.IsPreorder {
+		return nil
+	}
+	shipBy := truncateDay(now).AddDate(0, 0, produk.PreorderLeadDays)
+	return &shipBy
+}
+
+// claimPreorder takes kuantitas from the pre-order quota of a product inside tx
+func claimPreorder(tx *gorm.DB, produk *model.Produk, kuantitas int) error {
+	result := claimQuota(tx, model.Produk{}.TableName(), produk.ID, "preorder_sold", "preorder_quota", kuantitas)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("pre-order quota exhausted for product: " + produk.NamaProduk)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : price_history_usecase.go
+// Description  : Business logic untuk riwayat harga dan analitik harga produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic riwayat harga yang dicatat saat produk dibuat dan
+//   setiap kali harga diubah lewat UpdateProduk
+// - Analitik membagi penjualan (snapshot LogProduk) ke periode harga untuk
+//   membandingkan unit terjual per hari sebelum dan sesudah perubahan harga
+// - Penjualan dengan harga promo masuk ke periode harga asalnya dan dihitung
+//   juga sebagai promo_units_sold, revenue memakai harga_total yang dibayar
+// - Produk lama tanpa riwayat memakai harga saat ini sejak produk dibuat
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"math"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PriceHistoryUsecase interface
+type PriceHistoryUsecase interface {
+	GetPriceHistory(id, userID int) ([]model.PriceHistory, error)
+	GetPriceAnalytics(id, userID int) (*model.PriceAnalytics, error)
+}
+
+type priceHistoryUsecase struct {
+	priceHistoryRepo repository.PriceHistoryRepository
+	logProdukRepo    repository.LogProdukRepository
+	produkRepo       repository.ProdukRepository
+	tokoRepo         repository.TokoRepository
+}
+
+// NewPriceHistoryUsecase creates new price history usecase
+func NewPriceHistoryUsecase(
+	priceHistoryRepo repository.PriceHistoryRepository,
+	logProdukRepo repository.LogProdukRepository,
+	produkRepo repository.ProdukRepository,
+	tokoRepo repository.TokoRepository,
+) PriceHistoryUsecase {
+	return &priceHistoryUsecase{
+		priceHistoryRepo: priceHistoryRepo,
+		logProdukRepo:    logProdukRepo,
+		produkRepo:       produkRepo,
+		tokoRepo:         tokoRepo,
+	}
+}
+
+// GetPriceHistory lists the price changes of a visible product, owners can
+// also see the history of their unpublished products
+func (u *priceHistoryUsecase) GetPriceHistory(id, userID int) ([]model.PriceHistory, error) {
+	viewerTokoID := 0
+	if userID > 0 {
+		if toko, err := u.tokoRepo.FindByUserID(userID); err == nil {
+			viewerTokoID = toko.ID
+		}
+	}
+
+	produk, err := u.produkRepo.FindByIDWithRelations(id, viewerTokoID)
+	if err != nil {
+		return nil, errors.New("No Data Product")
+	}
+
+	return u.history(produk)
+}
+
+// GetPriceAnalytics summarizes units sold per price period of the user's product
+func (u *priceHistoryUsecase) GetPriceAnalytics(id, userID int) (*model.PriceAnalytics, error) {
+	produk, err := u.produkRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return nil, errors.New("toko not found")
+	}
+	if toko.IDUser != userID {
+		return nil, errors.New("unauthorized: not your product")
+	}
+
+	history, err := u.history(produk)
+	if err != nil {
+		return nil, err
+	}
+
+	sales, err := u.logProdukRepo.FindSalesByProdukID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceAnalytics(produk.ID, history, sales, time.Now()), nil
+}
+
+// history returns the stored price history, products without one get a
+// single entry with their current price
+func (u *priceHistoryUsecase) history(produk *model.Produk) ([]model.PriceHistory, error) {
+	history, err := u.priceHistoryRepo.FindByProdukID(produk.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 {
+		return history, nil
+	}
+
+	since := time.Now()
+	if produk.CreatedAt != nil {
+		since = *produk.CreatedAt
+	}
+	return []model.PriceHistory{{
+		IDProduk:      produk.ID,
+		HargaReseller: produk.HargaReseller,
+		HargaKonsumen: produk.HargaKonsumen,
+		ChangedAt:     since,
+	}}, nil
+}
+
+// recordPriceChange stores the current prices of a product inside tx, the
+// previous prices are stored first when the product has no history yet
+func recordPriceChange(tx *gorm.DB, produk *model.Produk, oldReseller, oldKonsumen string, now time.Time) error {
+	var count int64
+	if err := tx.Model(&model.PriceHistory{}).Where("id_produk = ?", produk.ID).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count == 0 {
+		since := now
+		if produk.CreatedAt != nil {
+			since = *produk.CreatedAt
+		}
+		initial := &model.PriceHistory{
+			IDProduk:      produk.ID,
+			HargaReseller: oldReseller,
+			HargaKonsumen: oldKonsumen,
+			ChangedAt:     since,
+		}
+		if err := tx.Create(initial).Error; err != nil {
+			return err
+		}
+	}
+
+	return recordPrice(tx, produk, now)
+}
+
+// recordPrice stores the current prices of a product inside tx
+func recordPrice(tx *gorm.DB, produk *model.Produk, now time.Time) error {
+	return tx.Create(&model.PriceHistory{
+		IDProduk:      produk.ID,
+		HargaReseller: produk.HargaReseller,
+		HargaKonsumen: produk.HargaKonsumen,
+		ChangedAt:     now,
+	}).Error
+}
+
+// priceAnalytics splits sales into the price periods of the history. Sales
+// only carry a date, so a sale on a day with several prices goes to the
+// period whose price matches its LogProduk snapshot.
+func priceAnalytics(produkID int, history []model.PriceHistory, sales []model.ProdukSale, now time.Time) *model.PriceAnalytics {
+	analytics := &model.PriceAnalytics{ProductID: produkID, Periods: make([]model.PricePeriod, len(history))}
+
+	for i, entry := range history {
+		period := &analytics.Periods[i]
+		period.HargaKonsumen, _ = strconv.Atoi(entry.HargaKonsumen)
+		period.HargaReseller, _ = strconv.Atoi(entry.HargaReseller)
+		period.StartAt = entry.ChangedAt
+
+		end := now
+		if i+1 < len(history) {
+			next := history[i+1].ChangedAt
+			period.EndAt = &next
+			end = next
+		}
+		period.Days = round2(end.Sub(entry.ChangedAt).Hours() / 24)
+	}
+
+	for _, sale := range sales {
+		i := salePeriod(analytics.Periods, sale)
+		analytics.Periods[i].UnitsSold += sale.Kuantitas
+		if sale.HargaPromo != nil {
+			analytics.Periods[i].PromoUnitsSold += sale.Kuantitas
+		}
+		analytics.Periods[i].Revenue += sale.HargaTotal
+		analytics.TotalUnits += sale.Kuantitas
+		analytics.TotalRevenue += sale.HargaTotal
+	}
+
+	for i := range analytics.Periods {
+		period := &analytics.Periods[i]
+		// Periods shorter than a day are rated as a full day
+		period.UnitsPerDay = round2(float64(period.UnitsSold) / math.Max(period.Days, 1))
+
+		if i == 0 {
+			continue
+		}
+		prev := analytics.Periods[i-1]
+		if prev.HargaKonsumen > 0 {
+			change := round2(float64(period.HargaKonsumen-prev.HargaKonsumen) * 100 / float64(prev.HargaKonsumen))
+			period.PriceChangePercent = &change
+		}
+		if prev.UnitsPerDay > 0 {
+			change := round2((period.UnitsPerDay - prev.UnitsPerDay) * 100 / prev.UnitsPerDay)
+			period.UnitsPerDayChange = &change
+		}
+	}
+
+	return analytics
+}
+
+// salePeriod returns the index of the period a sale belongs to. Periods are
+// list price periods, so a promo sale is matched on the list price it was
+// discounted from rather than on the promo price it was paid at.
+func salePeriod(periods []model.PricePeriod, sale model.ProdukSale) int {
+	saleDay := truncateDay(sale.SoldAt)
+	price, _ := strconv.Atoi(sale.HargaKonsumen)
+
+	match := -1
+	for i, period := range periods {
+		if saleDay.Before(truncateDay(period.StartAt)) {
+			break
+		}
+		if period.EndAt != nil && saleDay.After(truncateDay(*period.EndAt)) {
+			continue
+		}
+		if match == -1 || period.HargaKonsumen == price {
+			match = i
+		}
+	}
+
+	if match == -1 {
+		// Sales older than the first recorded price go to the first period,
+		// a gap after the last one cannot happen as it is open ended
+		return 0
+	}
+	return match
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
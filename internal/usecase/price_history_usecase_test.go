@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marchDay returns hour h of day d in March 2026
+func marchDay(d, h int) time.Time {
+	return time.Date(2026, 3, d, h, 0, 0, 0, time.UTC)
+}
+
+func testPeriods() []model.PricePeriod {
+	end1, end2 := marchDay(10, 15), marchDay(20, 9)
+	return []model.PricePeriod{
+		{HargaKonsumen: 100000, StartAt: marchDay(1, 8), EndAt: &end1},
+		{HargaKonsumen: 90000, StartAt: marchDay(10, 15), EndAt: &end2},
+		{HargaKonsumen: 120000, StartAt: marchDay(20, 9)},
+	}
+}
+
+func TestSalePeriod(t *testing.T) {
+	promo := 80000
+
+	tests := []struct {
+		name     string
+		sale     model.ProdukSale
+		expected int
+	}{
+		{"Inside First Period", model.ProdukSale{HargaKonsumen: "100000", SoldAt: marchDay(5, 0)}, 0},
+		{"Before First Period", model.ProdukSale{HargaKonsumen: "95000", SoldAt: marchDay(1, 0).AddDate(0, -1, 0)}, 0},
+		{"Change Day Old Price", model.ProdukSale{HargaKonsumen: "100000", SoldAt: marchDay(10, 0)}, 0},
+		{"Change Day New Price", model.ProdukSale{HargaKonsumen: "90000", SoldAt: marchDay(10, 0)}, 1},
+		{"Change Day Unknown Price", model.ProdukSale{HargaKonsumen: "1", SoldAt: marchDay(10, 0)}, 0},
+		{"Open Ended Last Period", model.ProdukSale{HargaKonsumen: "120000", SoldAt: marchDay(31, 0)}, 2},
+		{"Promo Matches List Price", model.ProdukSale{HargaKonsumen: "100000", HargaPromo: &promo, SoldAt: marchDay(10, 0)}, 0},
+		{"Promo At New List Price", model.ProdukSale{HargaKonsumen: "90000", HargaPromo: &promo, SoldAt: marchDay(20, 0)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, salePeriod(testPeriods(), tt.sale))
+		})
+	}
+}
+
+func TestPriceAnalytics(t *testing.T) {
+	promo := 70000
+	history := []model.PriceHistory{
+		{HargaKonsumen: "100000", HargaReseller: "80000", ChangedAt: marchDay(1, 0)},
+		{HargaKonsumen: "90000", HargaReseller: "70000", ChangedAt: marchDay(11, 0)},
+	}
+	sales := []model.ProdukSale{
+		{HargaKonsumen: "100000", Kuantitas: 2, HargaTotal: 200000, SoldAt: marchDay(2, 0)},
+		{HargaKonsumen: "100000", HargaPromo: &promo, Kuantitas: 3, HargaTotal: 210000, SoldAt: marchDay(11, 0)},
+		{HargaKonsumen: "90000", Kuantitas: 10, HargaTotal: 900000, SoldAt: marchDay(12, 0)},
+	}
+
+	analytics := priceAnalytics(1, history, sales, marchDay(21, 0))
+	require.Len(t, analytics.Periods, 2)
+
+	first, second := analytics.Periods[0], analytics.Periods[1]
+	assert.Equal(t, 100000, first.HargaKonsumen)
+	assert.Equal(t, 80000, first.HargaReseller)
+	assert.Equal(t, 10.0, first.Days)
+	assert.Equal(t, 5, first.UnitsSold)
+	assert.Equal(t, 3, first.PromoUnitsSold)
+	assert.Equal(t, 410000, first.Revenue)
+	assert.Equal(t, 0.5, first.UnitsPerDay)
+	assert.Nil(t, first.PriceChangePercent)
+
+	assert.Equal(t, 10, second.UnitsSold)
+	assert.Equal(t, 0, second.PromoUnitsSold)
+	assert.Equal(t, 1.0, second.UnitsPerDay)
+	require.NotNil(t, second.PriceChangePercent)
+	assert.Equal(t, -10.0, *second.PriceChangePercent)
+	require.NotNil(t, second.UnitsPerDayChange)
+	assert.Equal(t, 100.0, *second.UnitsPerDayChange)
+
+	assert.Equal(t, 15, analytics.TotalUnits)
+	assert.Equal(t, 1310000, analytics.TotalRevenue)
+}
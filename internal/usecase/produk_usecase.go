@@ -9,8 +9,17 @@
 //
 // Notes:
 // - File ini berisi logic untuk CRUD produk
-// - Menangani upload multiple foto produk
-// - Generate slug otomatis dari nama produk
+// - Menangani upload multiple foto produk beserta urutan dan cover
+// - File foto dihapus dari disk setelah transaksi database commit
+// - Produk yang di-soft delete dapat dipulihkan dari trash atau di-purge admin
+// - Generate slug unik per toko dari nama produk, slug lama tetap di-redirect
+// - Produk baru/diedit masuk antrian moderasi jika memerlukan approval
+// - Atribut produk divalidasi terhadap skema atribut kategori
+// - Tag bebas dan kategori sekunder disimpan bersama produk
+// - Setiap perubahan harga dicatat ke riwayat harga
+// - Produk bundle tersusun dari produk komponen toko yang sama
+// - Penambahan stok dari nol memicu notifikasi ke pelanggan stok
+// - Penurunan harga konsumen memicu notifikasi ke user yang menyimpan wishlist
 //
 // ============================================================================
 
@@ -20,9 +29,13 @@ import (
 	"errors"
 	"evermos-api/internal/model"
 	"evermos-api/internal/repository"
+	"evermos-api/internal/storage"
 	"evermos-api/internal/utils"
+	"fmt"
+	"log"
 	"mime/multipart"
 	"strconv"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -30,18 +43,36 @@ import (
 
 // ProdukUsecase interface
 type ProdukUsecase interface {
-	GetAllProduk(limit, offset int, filters map[string]string) (*model.PaginatedResponse, error)
-	GetProdukByID(id int) (*model.Produk, error)
-	CreateProduk(userID int, req model.CreateProdukRequest, files []*multipart.FileHeader, uploadPath string) (int, error)
-	UpdateProduk(id, userID int, req model.UpdateProdukRequest, files []*multipart.FileHeader, uploadPath string) error
-	DeleteProduk(id, userID int) error
+	GetAllProduk(limit, offset int, filters map[string]string, userID int) (*model.PaginatedResponse, error)
+	GetAllProdukByCursor(limit int, cursor string, filters map[string]string, userID int) (*model.PaginatedResponse, error)
+	GetMyProduk(userID, limit, offset int, status string) (*model.PaginatedResponse, error)
+	GetProdukByID(id, userID int) (*model.Produk, error)
+	GetProdukBySlug(slug, tokoSlug string, userID int) (*model.Produk, error)
+	CreateProduk(userID int, req model.CreateProdukRequest, files []*multipart.FileHeader, store storage.Storage) (int, error)
+	UpdateProduk(id, userID int, req model.UpdateProdukRequest, files []*multipart.FileHeader, store storage.Storage) error
+	DeleteProduk(id, userID int, store storage.Storage) error
+	GetTrashProduk(userID, limit, offset int) (*model.PaginatedResponse, error)
+	RestoreProduk(id, userID int) error
+	PurgeDeletedProduk(cutoff time.Time, store storage.Storage) (int, error)
+	AddProdukPhotos(id, userID int, files []*multipart.FileHeader, store storage.Storage) ([]model.FotoProduk, error)
+	DeleteProdukPhoto(id, photoID, userID int, store storage.Storage) error
+	ReorderProdukPhotos(id, userID int, photoIDs []int) error
+	SetProdukCoverPhoto(id, photoID, userID int) error
+	PublishProduk(id, userID int, req model.PublishProdukRequest) error
+	UnpublishProduk(id, userID int) error
 }
 
 type produkUsecase struct {
 	produkRepo     repository.ProdukRepository
 	tokoRepo       repository.TokoRepository
+	categoryRepo   repository.CategoryRepository
+	attributeRepo  repository.CategoryAttributeRepository
+	promoRepo      repository.ProdukPromoRepository
+	wishlistRepo   repository.WishlistRepository
 	fotoProdukRepo repository.FotoProdukRepository
 	logProdukRepo  repository.LogProdukRepository
+	slugRepo       repository.SlugRedirectRepository
+	moderation     ModerationUsecase
 	db             *gorm.DB
 }
 
@@ -49,21 +80,186 @@ type produkUsecase struct {
 func NewProdukUsecase(
 	produkRepo repository.ProdukRepository,
 	tokoRepo repository.TokoRepository,
+	categoryRepo repository.CategoryRepository,
+	attributeRepo repository.CategoryAttributeRepository,
+	promoRepo repository.ProdukPromoRepository,
+	wishlistRepo repository.WishlistRepository,
 	fotoProdukRepo repository.FotoProdukRepository,
 	logProdukRepo repository.LogProdukRepository,
+	slugRepo repository.SlugRedirectRepository,
+	moderation ModerationUsecase,
 	db *gorm.DB,
 ) ProdukUsecase {
 	return &produkUsecase{
 		produkRepo:     produkRepo,
 		tokoRepo:       tokoRepo,
+		categoryRepo:   categoryRepo,
+		attributeRepo:  attributeRepo,
+		promoRepo:      promoRepo,
+		wishlistRepo:   wishlistRepo,
 		fotoProdukRepo: fotoProdukRepo,
 		logProdukRepo:  logProdukRepo,
+		slugRepo:       slugRepo,
+		moderation:     moderation,
 		db:             db,
 	}
 }
 
-func (u *produkUsecase) GetAllProduk(limit, offset int, filters map[string]string) (*model.PaginatedResponse, error) {
-	// Convert string filters to appropriate types
+func (u *produkUsecase) GetAllProduk(limit, offset int, filters map[string]string, userID int) (*model.PaginatedResponse, error) {
+	produks, err := u.produkRepo.FindAll(limit, offset, u.produkFilters(filters))
+	if err != nil {
+		return nil, err
+	}
+	u.decorateProduks(produks)
+	fillWishlisted(u.wishlistRepo, userID, produks)
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  produks,
+	}, nil
+}
+
+func (u *produkUsecase) GetAllProdukByCursor(limit int, cursor string, filters map[string]string, userID int) (*model.PaginatedResponse, error) {
+	decoded, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	produks, err := u.produkRepo.FindAllByCursor(limit, decoded, u.produkFilters(filters))
+	if err != nil {
+		return nil, err
+	}
+	u.decorateProduks(produks)
+	fillWishlisted(u.wishlistRepo, userID, produks)
+
+	// Popular listings page by score, the cursor carries it as key
+	popular := filters["sort"] == model.ProdukSortPopular
+	produks, next, prev := utils.CursorPage(produks, limit, decoded, func(p model.Produk) (string, int) {
+		if popular {
+			return strconv.Itoa(p.PopularityScore), p.ID
+		}
+		return "", p.ID
+	})
+
+	return &model.PaginatedResponse{
+		Limit:      limit,
+		NextCursor: next,
+		PrevCursor: prev,
+		Data:       produks,
+	}, nil
+}
+
+// produkFilters parses query filters, a category filter also matches all of
+// its sub-categories
+func (u *produkUsecase) produkFilters(filters map[string]string) map[string]interface{} {
+	filterMap := parseProdukFilters(filters)
+	if categoryID, ok := filterMap["category_id"].(int); ok {
+		if categories, err := u.categoryRepo.FindAll(); err == nil {
+			delete(filterMap, "category_id")
+			filterMap["category_ids"] = newCategoryIndex(categories).descendantIDs(categoryID)
+		}
+	}
+	return filterMap
+}
+
+// produkAttributes validates raw attribute values against the schema of a category
+func (u *produkUsecase) produkAttributes(categoryID int, raw string) ([]model.ProdukAttribute, error) {
+	values, err := parseAttributeValues(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := effectiveAttributes(u.categoryRepo, u.attributeRepo, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	return validateAttributeValues(schema, values)
+}
+
+// revalidateProdukAttributes checks the stored attributes of a product against
+// the schema of its (new) category
+func (u *produkUsecase) revalidateProdukAttributes(produk *model.Produk) ([]model.ProdukAttribute, error) {
+	var stored []model.ProdukAttribute
+	if err := u.db.Where("id_produk = ?", produk.ID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+
+	schema, err := effectiveAttributes(u.categoryRepo, u.attributeRepo, produk.IDCategory)
+	if err != nil {
+		return nil, err
+	}
+	return validateAttributeValues(schema, attributeValues(stored))
+}
+
+// secondaryCategories validates a comma separated list of secondary category
+// IDs, the primary category is dropped from the list
+func (u *produkUsecase) secondaryCategories(primaryID int, raw string) ([]int, error) {
+	ids, err := parseCategoryIDs(raw)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	index := newCategoryIndex(categories)
+
+	var result []int
+	seen := map[int]bool{primaryID: true}
+	for _, id := range ids {
+		if _, ok := index.byID[id]; !ok {
+			return nil, fmt.Errorf("category %d not found", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+
+	if len(result) > maxSecondaryCategories {
+		return nil, fmt.Errorf("a product can have at most %d secondary categories", maxSecondaryCategories)
+	}
+	return result, nil
+}
+
+// replaceProdukAttributes swaps the stored attributes of a product
+func replaceProdukAttributes(tx *gorm.DB, produkID int, attributes []model.ProdukAttribute) error {
+	if err := tx.Where("id_produk = ?", produkID).Delete(&model.ProdukAttribute{}).Error; err != nil {
+		return err
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	for i := range attributes {
+		attributes[i].IDProduk = produkID
+	}
+	return tx.Create(&attributes).Error
+}
+
+// decorateProduks fills the computed fields of products in a response
+func (u *produkUsecase) decorateProduks(produks []model.Produk) {
+	u.fillBreadcrumbs(produks)
+	fillPromos(u.promoRepo, produks, time.Now())
+	fillBundleStock(produks)
+}
+
+// fillBreadcrumbs sets the category path of each product
+func (u *produkUsecase) fillBreadcrumbs(produks []model.Produk) {
+	categories, err := u.categoryRepo.FindAll()
+	if err != nil {
+		return
+	}
+
+	index := newCategoryIndex(categories)
+	for i := range produks {
+		produks[i].Breadcrumb = index.breadcrumb(produks[i].IDCategory)
+	}
+}
+
+// parseProdukFilters converts string query filters to appropriate types
+func parseProdukFilters(filters map[string]string) map[string]interface{} {
 	filterMap := make(map[string]interface{})
 
 	if namaProduk, ok := filters["nama_produk"]; ok {
@@ -90,10 +286,63 @@ func (u *produkUsecase) GetAllProduk(limit, offset int, filters map[string]strin
 		}
 	}
 
-	produks, err := u.produkRepo.FindAll(limit, offset, filterMap)
+	if tag, ok := filters["tag"]; ok {
+		var tags []string
+		for _, part := range strings.Split(tag, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			// Slug the name the way stored tags are, fallback slugs of
+			// emoji or CJK names hash the normalized name. A name no tag
+			// can have still filters, matching nothing.
+			if name, err := normalizeTagName(part); err == nil {
+				part = name
+			}
+			tags = append(tags, utils.GenerateSlug(part))
+		}
+		if len(tags) > 0 {
+			filterMap["tags"] = tags
+		}
+	}
+
+	// Attribute filters arrive as attr.<name>=<value>
+	attributes := make(map[string][]string)
+	for key, value := range filters {
+		if name := strings.TrimPrefix(key, "attr."); name != key && name != "" {
+			attributes[name] = attributeFilterValues(value)
+		}
+	}
+	if len(attributes) > 0 {
+		filterMap["attributes"] = attributes
+	}
+
+	if sort, ok := filters["sort"]; ok {
+		filterMap["sort"] = sort
+	}
+
+	return filterMap
+}
+
+// GetMyProduk lists the user's own products including drafts and scheduled ones
+func (u *produkUsecase) GetMyProduk(userID, limit, offset int, status string) (*model.PaginatedResponse, error) {
+	toko, err := u.tokoRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("you don't have a toko")
+	}
+
+	produks, err := u.produkRepo.FindAll(limit, offset, map[string]interface{}{
+		"toko_id":             toko.ID,
+		"status":              status,
+		"include_unpublished": true,
+	})
 	if err != nil {
 		return nil, err
 	}
+	u.decorateProduks(produks)
+	fillWishlistCounts(u.wishlistRepo, produks)
+	for i := range produks {
+		produks[i].SellerThreshold = &produks[i].LowStockThreshold
+	}
 
 	return &model.PaginatedResponse{
 		Page:  (offset / limit) + 1,
@@ -102,70 +351,249 @@ func (u *produkUsecase) GetAllProduk(limit, offset int, filters map[string]strin
 	}, nil
 }
 
-func (u *produkUsecase) GetProdukByID(id int) (*model.Produk, error) {
-	produk, err := u.produkRepo.FindByIDWithRelations(id)
+// GetProdukByID gets a published product, owners can also preview unpublished ones
+func (u *produkUsecase) GetProdukByID(id, userID int) (*model.Produk, error) {
+	viewerTokoID := 0
+	if userID > 0 {
+		if toko, err := u.tokoRepo.FindByUserID(userID); err == nil {
+			viewerTokoID = toko.ID
+		}
+	}
+
+	produk, err := u.produkRepo.FindByIDWithRelations(id, viewerTokoID)
 	if err != nil {
 		return nil, errors.New("No Data Product")
 	}
-	return produk, nil
+
+	produks := []model.Produk{*produk}
+	u.decorateProduks(produks)
+	fillWishlisted(u.wishlistRepo, userID, produks)
+	if viewerTokoID > 0 && produk.IDToko == viewerTokoID {
+		fillWishlistCounts(u.wishlistRepo, produks)
+	}
+	return &produks[0], nil
+}
+
+// GetProdukBySlug resolves a product by its current or former slug, tokoSlug
+// is required only when several toko use the same product slug
+func (u *produkUsecase) GetProdukBySlug(slug, tokoSlug string, userID int) (*model.Produk, error) {
+	tokoID := 0
+	if tokoSlug != "" {
+		toko, err := u.findTokoBySlug(tokoSlug)
+		if err != nil {
+			return nil, errors.New("toko not found")
+		}
+		tokoID = toko.ID
+	}
+
+	produks, err := u.produkRepo.FindAllBySlug(slug, tokoID)
+	if err != nil {
+		return nil, err
+	}
+	if len(produks) > 1 {
+		return nil, ErrAmbiguousSlug
+	}
+	if len(produks) == 1 {
+		u.decorateProduks(produks)
+		fillWishlisted(u.wishlistRepo, userID, produks)
+		return &produks[0], nil
+	}
+
+	// Fall back to slugs the product used before it was renamed
+	var targetID int
+	if tokoID > 0 {
+		redirect, err := u.slugRepo.FindBySlug(model.SlugEntityProduk, tokoID, slug)
+		if err != nil {
+			return nil, errors.New("No Data Product")
+		}
+		targetID = redirect.IDTarget
+	} else {
+		redirects, err := u.slugRepo.FindAllBySlug(model.SlugEntityProduk, slug)
+		if err != nil {
+			return nil, err
+		}
+		if len(redirects) > 1 {
+			return nil, ErrAmbiguousSlug
+		}
+		if len(redirects) == 0 {
+			return nil, errors.New("No Data Product")
+		}
+		targetID = redirects[0].IDTarget
+	}
+
+	return u.GetProdukByID(targetID, userID)
+}
+
+// findTokoBySlug resolves a toko by its current or former slug
+func (u *produkUsecase) findTokoBySlug(slug string) (*model.Toko, error) {
+	toko, err := u.tokoRepo.FindBySlug(slug)
+	if err == nil {
+		return toko, nil
+	}
+
+	redirect, err := u.slugRepo.FindBySlug(model.SlugEntityToko, 0, slug)
+	if err != nil {
+		return nil, err
+	}
+	return u.tokoRepo.FindByID(redirect.IDTarget)
 }
 
-func (u *produkUsecase) CreateProduk(userID int, req model.CreateProdukRequest, files []*multipart.FileHeader, uploadPath string) (int, error) {
+func (u *produkUsecase) CreateProduk(userID int, req model.CreateProdukRequest, files []*multipart.FileHeader, store storage.Storage) (int, error) {
 	// Get user's toko
 	toko, err := u.tokoRepo.FindByUserID(userID)
 	if err != nil {
 		return 0, errors.New("you don't have a toko")
 	}
 
+	tags, err := parseTagNames(req.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := u.moderation.CheckContent(req.NamaProduk, req.Deskripsi, strings.Join(tags, ", ")); err != nil {
+		return 0, err
+	}
+
+	categoryIDs, err := u.secondaryCategories(req.CategoryID, req.CategoryIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	produkType := model.ProdukTypeSingle
+	var bundleItems []model.BundleItem
+	if req.Type == model.ProdukTypeBundle {
+		if req.BundleItems == "" {
+			return 0, errors.New("bundle_items is required for bundle products")
+		}
+		if bundleItems, err = u.bundleItems(toko.ID, 0, req.BundleItems); err != nil {
+			return 0, err
+		}
+		// Bundle stock is derived from its components
+		produkType = model.ProdukTypeBundle
+		req.Stok = 0
+	} else if req.BundleItems != "" {
+		return 0, errors.New("bundle_items is only allowed for bundle products")
+	}
+	if req.Type == model.ProdukTypeDigital {
+		// Digital products are delivered as downloads and never run out
+		produkType = model.ProdukTypeDigital
+		req.Stok = 0
+	}
+
 	now := time.Now()
-	slug := utils.GenerateSlug(req.NamaProduk)
+	status, publishAt, err := resolvePublishState(req.Status, req.PublishAt, now)
+	if err != nil {
+		return 0, err
+	}
+
+	attributes, err := u.produkAttributes(req.CategoryID, req.Attributes)
+	if err != nil {
+		return 0, err
+	}
+
+	moderationStatus := model.ModerationApproved
+	if u.moderation.RequiresApproval(toko, req.CategoryID) {
+		moderationStatus = model.ModerationPending
+	}
 
 	produk := &model.Produk{
-		NamaProduk:    req.NamaProduk,
-		Slug:          slug,
-		HargaReseller: req.HargaReseller,
-		HargaKonsumen: req.HargaKonsumen,
-		Stok:          req.Stok,
-		Deskripsi:     req.Deskripsi,
-		IDToko:        toko.ID,
-		IDCategory:    req.CategoryID,
-		CreatedAt:     &now,
-		UpdatedAt:     &now,
-	}
-
-	return produk.ID, u.db.Transaction(func(tx *gorm.DB) error {
-		// Create produk
-		if err := tx.Create(produk).Error; err != nil {
+		NamaProduk:        req.NamaProduk,
+		Type:              produkType,
+		Status:            status,
+		PublishAt:         publishAt,
+		ModerationStatus:  moderationStatus,
+		HargaReseller:     req.HargaReseller,
+		HargaKonsumen:     req.HargaKonsumen,
+		Stok:              req.Stok,
+		LowStockThreshold: req.LowStockThreshold,
+		IsPreorder:        req.IsPreorder,
+		PreorderLeadDays:  req.PreorderLeadDays,
+		PreorderQuota:     req.PreorderQuota,
+		DownloadLimit:     req.DownloadLimit,
+		DownloadDays:      req.DownloadDays,
+		Deskripsi:         req.Deskripsi,
+		IDToko:            toko.ID,
+		IDCategory:        req.CategoryID,
+		CreatedAt:         &now,
+		UpdatedAt:         &now,
+	}
+	if err := validatePreorder(produk); err != nil {
+		return 0, err
+	}
+	if err := validateDigital(produk, req.DigitalFile); err != nil {
+		return 0, err
+	}
+
+	var uploaded []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		if req.DigitalFile != nil {
+			if err := uploadDigitalFile(produk, req.DigitalFile, store); err != nil {
+				return err
+			}
+			uploaded = append(uploaded, produk.DigitalFile)
+		}
+
+		// Create produk under a slug unique within the toko
+		err := saveWithSlug(tx, model.SlugEntityProduk, toko.ID, 0, req.NamaProduk, func(slug string) error {
+			produk.Slug = slug
+			return tx.Create(produk).Error
+		})
+		if err != nil {
 			return err
 		}
 
-		// Create foto produk if files provided
-		if len(files) > 0 {
-			for _, file := range files {
-				// Upload file using utility
-				urlFoto, err := utils.UploadFile(file, uploadPath, "produk")
-				if err != nil {
-					return err
-				}
+		if err := replaceProdukAttributes(tx, produk.ID, attributes); err != nil {
+			return err
+		}
+		if err := replaceProdukTags(tx, produk.ID, tags); err != nil {
+			return err
+		}
+		if err := replaceProdukCategories(tx, produk.ID, categoryIDs); err != nil {
+			return err
+		}
+		if err := recordPrice(tx, produk, now); err != nil {
+			return err
+		}
+		if err := replaceBundleItems(tx, produk.ID, bundleItems); err != nil {
+			return err
+		}
 
-				foto := &model.FotoProduk{
-					IDProduk: produk.ID,
-					//URL:       file.Filename, // In production, use upload utility
-					URL:       urlFoto,
-					CreatedAt: &now,
-					UpdatedAt: &now,
-				}
-				if err := tx.Create(foto).Error; err != nil {
-					return err
-				}
+		// Create foto produk if files provided, first photo becomes cover
+		for i, file := range files {
+			// Upload file using utility
+			img, err := utils.UploadImage(file, store, "produk", utils.ImageProcessing.ThumbnailSizes)
+			if err != nil {
+				return err
+			}
+
+			foto := &model.FotoProduk{
+				IDProduk: produk.ID,
+				//URL:       file.Filename, // In production, use upload utility
+				URL:       img.URL,
+				Sizes:     img.Sizes,
+				Posisi:    i,
+				IsCover:   i == 0,
+				CreatedAt: &now,
+				UpdatedAt: &now,
+			}
+			uploaded = append(uploaded, foto.Files()...)
+			if err := tx.Create(foto).Error; err != nil {
+				return err
 			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		// Rolled back rows must not leave their files behind
+		removeFiles(store, uploaded)
+		return 0, err
+	}
+
+	return produk.ID, nil
 }
 
-func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukRequest, files []*multipart.FileHeader, uploadPath string) error {
+func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukRequest, files []*multipart.FileHeader, store storage.Storage) error {
 	produk, err := u.produkRepo.FindByID(id)
 	if err != nil {
 		return errors.New("product not found")
@@ -181,9 +609,14 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 	}
 
 	// Update fields if provided
+	renamed := req.NamaProduk != "" && req.NamaProduk != produk.NamaProduk
+	recategorized := req.CategoryID > 0 && req.CategoryID != produk.IDCategory
+	oldReseller, oldKonsumen := produk.HargaReseller, produk.HargaKonsumen
+	oldStok := produk.Stok
+	edited := renamed || recategorized || len(files) > 0 || req.DigitalFile != nil ||
+		(req.Deskripsi != "" && req.Deskripsi != produk.Deskripsi)
 	if req.NamaProduk != "" {
 		produk.NamaProduk = req.NamaProduk
-		produk.Slug = utils.GenerateSlug(req.NamaProduk)
 	}
 	if req.HargaReseller != "" {
 		produk.HargaReseller = req.HargaReseller
@@ -191,9 +624,43 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 	if req.HargaKonsumen != "" {
 		produk.HargaKonsumen = req.HargaKonsumen
 	}
-	if req.Stok > 0 {
+	if req.Stok > 0 && produk.Type != model.ProdukTypeBundle && produk.Type != model.ProdukTypeDigital {
 		produk.Stok = req.Stok
 	}
+	if req.LowStockThreshold != nil {
+		produk.LowStockThreshold = *req.LowStockThreshold
+	}
+	if req.IsPreorder != nil {
+		// Bundle stock comes from physical component stock
+		if *req.IsPreorder && !produk.IsPreorder {
+			count, err := countBundlesContaining(u.db, produk.ID)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return errors.New("a bundle component cannot be a pre-order product")
+			}
+		}
+		produk.IsPreorder = *req.IsPreorder
+	}
+	if req.PreorderLeadDays != nil {
+		produk.PreorderLeadDays = *req.PreorderLeadDays
+	}
+	if req.PreorderQuota != nil {
+		produk.PreorderQuota = *req.PreorderQuota
+	}
+	if err := validatePreorder(produk); err != nil {
+		return err
+	}
+	if req.DownloadLimit != nil {
+		produk.DownloadLimit = *req.DownloadLimit
+	}
+	if req.DownloadDays != nil {
+		produk.DownloadDays = *req.DownloadDays
+	}
+	if err := validateDigital(produk, req.DigitalFile); err != nil {
+		return err
+	}
 	if req.Deskripsi != "" {
 		produk.Deskripsi = req.Deskripsi
 	}
@@ -201,16 +668,135 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 		produk.IDCategory = req.CategoryID
 	}
 
+	// Omitted tags and secondary categories are kept, an empty value clears them
+	var tags []string
+	if req.Tags != nil {
+		if tags, err = parseTagNames(*req.Tags); err != nil {
+			return err
+		}
+	}
+
+	if err := u.moderation.CheckContent(produk.NamaProduk, produk.Deskripsi, strings.Join(tags, ", ")); err != nil {
+		return err
+	}
+
+	var categoryIDs []int
+	if req.CategoryIDs != nil {
+		if categoryIDs, err = u.secondaryCategories(produk.IDCategory, *req.CategoryIDs); err != nil {
+			return err
+		}
+	}
+
+	var bundleItems []model.BundleItem
+	if req.BundleItems != "" {
+		if produk.Type != model.ProdukTypeBundle {
+			return errors.New("bundle_items is only allowed for bundle products")
+		}
+		if bundleItems, err = u.bundleItems(produk.IDToko, produk.ID, req.BundleItems); err != nil {
+			return err
+		}
+	}
+
+	// New attributes replace the stored ones, a category change without new
+	// attributes revalidates the stored ones against the new schema
+	var attributes []model.ProdukAttribute
+	updateAttributes := req.Attributes != "" || recategorized
+	if req.Attributes != "" {
+		attributes, err = u.produkAttributes(produk.IDCategory, req.Attributes)
+	} else if recategorized {
+		attributes, err = u.revalidateProdukAttributes(produk)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Content edits go back to the moderation queue when approval is required
+	if edited {
+		produk.ModerationStatus = u.moderationAfterEdit(produk, toko)
+	}
+
 	now := time.Now()
 	produk.UpdatedAt = &now
 
-	return u.db.Transaction(func(tx *gorm.DB) error {
-		// Update produk
-		if err := tx.Save(produk).Error; err != nil {
+	var uploaded, replaced []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		// A new digital file only applies to future purchases, the old one is
+		// kept while buyers can still download it
+		if req.DigitalFile != nil {
+			oldFile := produk.DigitalFile
+			if err := uploadDigitalFile(produk, req.DigitalFile, store); err != nil {
+				return err
+			}
+			uploaded = append(uploaded, produk.DigitalFile)
+
+			remove, err := releaseDigitalFile(tx, oldFile, now)
+			if err != nil {
+				return err
+			}
+			if remove {
+				replaced = append(replaced, oldFile)
+			}
+		}
+
+		// Update produk, a rename regenerates the slug and the old one keeps
+		// redirecting here
+		if renamed {
+			oldSlug := produk.Slug
+			err := saveWithSlug(tx, model.SlugEntityProduk, produk.IDToko, produk.ID, produk.NamaProduk, func(slug string) error {
+				produk.Slug = slug
+				return tx.Save(produk).Error
+			})
+			if err != nil {
+				return err
+			}
+			if err := recordSlugChange(tx, model.SlugEntityProduk, produk.IDToko, produk.ID, oldSlug, produk.Slug); err != nil {
+				return err
+			}
+		} else if err := tx.Save(produk).Error; err != nil {
+			return err
+		}
+
+		if len(bundleItems) > 0 {
+			if err := replaceBundleItems(tx, produk.ID, bundleItems); err != nil {
+				return err
+			}
+		}
+
+		if err := notifyRestock(tx, produk, oldStok); err != nil {
 			return err
 		}
 
-		// Handle file uploads if provided
+		if produk.HargaReseller != oldReseller || produk.HargaKonsumen != oldKonsumen {
+			if err := recordPriceChange(tx, produk, oldReseller, oldKonsumen, now); err != nil {
+				return err
+			}
+			if err := notifyPriceDrop(tx, produk, oldKonsumen); err != nil {
+				return err
+			}
+		}
+
+		if updateAttributes {
+			if err := replaceProdukAttributes(tx, produk.ID, attributes); err != nil {
+				return err
+			}
+		}
+		if req.Tags != nil {
+			if err := replaceProdukTags(tx, produk.ID, tags); err != nil {
+				return err
+			}
+		}
+		if req.CategoryIDs != nil {
+			if err := replaceProdukCategories(tx, produk.ID, categoryIDs); err != nil {
+				return err
+			}
+		} else if recategorized {
+			// The new primary category cannot stay listed as secondary
+			if err := tx.Where("id_produk = ? AND id_category = ?", produk.ID, produk.IDCategory).Delete(&model.ProdukCategory{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Handle file uploads if provided, replacing the whole gallery
 		if len(files) > 0 {
 			// Get old photos for deletion
 			oldPhotos, _ := u.fotoProdukRepo.FindByProdukID(id)
@@ -220,15 +806,14 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 				return err
 			}
 
-			// Delete old photo files
 			for _, oldPhoto := range oldPhotos {
-				_ = utils.DeleteFile(uploadPath, oldPhoto.URL)
+				replaced = append(replaced, oldPhoto.Files()...)
 			}
 
 			// Upload and create new photos
-			for _, file := range files {
+			for i, file := range files {
 				// Upload file using utility
-				urlFoto, err := utils.UploadFile(file, uploadPath, "produk")
+				img, err := utils.UploadImage(file, store, "produk", utils.ImageProcessing.ThumbnailSizes)
 				if err != nil {
 					return err
 				}
@@ -236,10 +821,14 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 				foto := &model.FotoProduk{
 					IDProduk: produk.ID,
 					//URL:       file.Filename,
-					URL:       urlFoto,
+					URL:       img.URL,
+					Sizes:     img.Sizes,
+					Posisi:    i,
+					IsCover:   i == 0,
 					CreatedAt: &now,
 					UpdatedAt: &now,
 				}
+				uploaded = append(uploaded, foto.Files()...)
 				if err := tx.Create(foto).Error; err != nil {
 					return err
 				}
@@ -248,9 +837,17 @@ func (u *produkUsecase) UpdateProduk(id, userID int, req model.UpdateProdukReque
 
 		return nil
 	})
+	if err != nil {
+		removeFiles(store, uploaded)
+		return err
+	}
+
+	// Old files are only removed once the new rows are committed
+	removeFiles(store, replaced)
+	return nil
 }
 
-func (u *produkUsecase) DeleteProduk(id, userID int) error {
+func (u *produkUsecase) DeleteProduk(id, userID int, store storage.Storage) error {
 	produk, err := u.produkRepo.FindByID(id)
 	if err != nil {
 		return errors.New("record not found")
@@ -265,6 +862,15 @@ func (u *produkUsecase) DeleteProduk(id, userID int) error {
 		return errors.New("unauthorized: not your product")
 	}
 
+	// Products sold as part of a bundle must be removed from it first
+	bundles, err := countBundlesContaining(u.db, id)
+	if err != nil {
+		return errors.New("failed to check product bundles")
+	}
+	if bundles > 0 {
+		return errors.New("product is part of a bundle, remove it from the bundle first")
+	}
+
 	// Check if product has been used in transactions
 	hasTransaction, err := u.logProdukRepo.ExistsByProdukID(id)
 	if err != nil {
@@ -274,31 +880,423 @@ func (u *produkUsecase) DeleteProduk(id, userID int) error {
 	// If product has transactions, use soft delete
 	if hasTransaction {
 		now := time.Now()
-		produk.DeletedAt = &now
-		return u.produkRepo.Update(produk)
+		return updateProdukColumns(u.db, id, map[string]interface{}{
+			"deleted_at": now,
+			"updated_at": now,
+		})
 	}
 
+	// Get photos for deletion
+	photos, _ := u.fotoProdukRepo.FindByProdukID(id)
+
 	// If no transactions, perform hard delete
-	return u.db.Transaction(func(tx *gorm.DB) error {
-		// Get photos for deletion
-		photos, _ := u.fotoProdukRepo.FindByProdukID(id)
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		return deleteProdukRows(tx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Delete photo and digital files after commit, an unsold product has no
+	// download grants
+	urls := make([]string, 0, len(photos)+1)
+	for _, photo := range photos {
+		urls = append(urls, photo.Files()...)
+	}
+	if produk.DigitalFile != "" {
+		urls = append(urls, produk.DigitalFile)
+	}
+	removeFiles(store, urls)
 
-		// Delete photos from database first
-		if err := tx.Where("id_produk = ?", id).Delete(&model.FotoProduk{}).Error; err != nil {
+	return nil
+}
+
+// deleteProdukRows hard-deletes a product with every row that refers to it
+// inside tx, LogProduk snapshots are kept for transaction history
+func deleteProdukRows(tx *gorm.DB, id int) error {
+	byProduk := []interface{}{
+		&model.FotoProduk{},
+		&model.ProdukAttribute{},
+		&model.ProdukTag{},
+		&model.ProdukCategory{},
+		&model.ProdukPromo{},
+		&model.PriceHistory{},
+		&model.StockSubscription{},
+		&model.ProdukViewDaily{},
+		&model.Wishlist{},
+	}
+	for _, m := range byProduk {
+		if err := tx.Where("id_produk = ?", id).Delete(m).Error; err != nil {
 			return err
 		}
+	}
+
+	if err := tx.Where("id_bundle = ?", id).Delete(&model.BundleItem{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id_produk = ? OR id_related = ?", id, id).Delete(&model.ProdukCoPurchase{}).Error; err != nil {
+		return err
+	}
+	// Old slugs must not redirect to a product that no longer exists
+	if err := tx.Where("entity = ? AND id_target = ?", model.SlugEntityProduk, id).Delete(&model.SlugRedirect{}).Error; err != nil {
+		return err
+	}
+	return tx.Delete(&model.Produk{}, id).Error
+}
+
+// GetTrashProduk lists the user's soft-deleted products
+func (u *produkUsecase) GetTrashProduk(userID, limit, offset int) (*model.PaginatedResponse, error) {
+	toko, err := u.tokoRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, errors.New("you don't have a toko")
+	}
+
+	produks, err := u.produkRepo.FindDeletedByTokoID(toko.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  produks,
+	}, nil
+}
+
+// RestoreProduk brings a soft-deleted product back from the trash
+func (u *produkUsecase) RestoreProduk(id, userID int) error {
+	produk, err := u.produkRepo.FindDeletedByID(id)
+	if err != nil {
+		return errors.New("product not found in trash")
+	}
+
+	// Check ownership
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return errors.New("toko not found")
+	}
+	if toko.IDUser != userID {
+		return errors.New("unauthorized: not your product")
+	}
+
+	return updateProdukColumns(u.db, id, map[string]interface{}{
+		"deleted_at": nil,
+		"updated_at": time.Now(),
+	})
+}
+
+// PurgeDeletedProduk hard-deletes products soft-deleted before cutoff together
+// with their photos, LogProduk snapshots are kept. Retained digital files no
+// buyer can download anymore are removed too. Returns the purged count.
+func (u *produkUsecase) PurgeDeletedProduk(cutoff time.Time, store storage.Storage) (int, error) {
+	const batchSize = 100
+
+	purged := 0
+	for {
+		produks, err := u.produkRepo.FindDeletedBefore(cutoff, batchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		for _, produk := range produks {
+			removeDigital := false
+			err := u.db.Transaction(func(tx *gorm.DB) error {
+				// The digital file is kept while buyers can still download it
+				if produk.DigitalFile != "" {
+					remove, err := releaseDigitalFile(tx, produk.DigitalFile, time.Now())
+					if err != nil {
+						return err
+					}
+					removeDigital = remove
+				}
+
+				return deleteProdukRows(tx, produk.ID)
+			})
+			if err != nil {
+				return purged, err
+			}
+
+			// Delete photo files after commit, a retained digital file is
+			// left for the sweep below
+			var urls []string
+			for _, photo := range produk.Photos {
+				urls = append(urls, photo.Files()...)
+			}
+			if removeDigital {
+				urls = append(urls, produk.DigitalFile)
+			}
+			removeFiles(store, urls)
+			purged++
+		}
+
+		if len(produks) < batchSize {
+			break
+		}
+	}
+
+	// Digital files kept for buyers go once their grants are used up
+	if _, err := sweepDigitalFiles(u.db, store, time.Now()); err != nil {
+		return purged, err
+	}
+	return purged, nil
+}
+
+func (u *produkUsecase) AddProdukPhotos(id, userID int, files []*multipart.FileHeader, store storage.Storage) ([]model.FotoProduk, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no photos uploaded")
+	}
+
+	produk, err := u.findOwnedProduk(id, userID)
+	if err != nil {
+		return nil, err
+	}
 
-		// Delete photo files
-		// Note: uploadPath should be passed or obtained from config
-		// For now, we'll skip physical deletion in delete operation
-		// You can add uploadPath as parameter if needed
-		_ = photos
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return nil, errors.New("toko not found")
+	}
+	moderationStatus := u.moderationAfterEdit(produk, toko)
 
-		// Delete product
-		if err := tx.Delete(&model.Produk{}, id).Error; err != nil {
+	existing, err := u.fotoProdukRepo.FindByProdukID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Append after the current last position, keep existing cover
+	nextPosisi := 0
+	hasCover := false
+	for _, photo := range existing {
+		if photo.Posisi >= nextPosisi {
+			nextPosisi = photo.Posisi + 1
+		}
+		hasCover = hasCover || photo.IsCover
+	}
+
+	now := time.Now()
+	var created []model.FotoProduk
+	var uploaded []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		for i, file := range files {
+			img, err := utils.UploadImage(file, store, "produk", utils.ImageProcessing.ThumbnailSizes)
+			if err != nil {
+				return err
+			}
+
+			foto := model.FotoProduk{
+				IDProduk:  id,
+				URL:       img.URL,
+				Sizes:     img.Sizes,
+				Posisi:    nextPosisi + i,
+				IsCover:   !hasCover && i == 0,
+				CreatedAt: &now,
+				UpdatedAt: &now,
+			}
+			uploaded = append(uploaded, foto.Files()...)
+			if err := tx.Create(&foto).Error; err != nil {
+				return err
+			}
+			created = append(created, foto)
+		}
+
+		if moderationStatus != produk.ModerationStatus {
+			return tx.Model(&model.Produk{}).Where("id = ?", id).Update("moderation_status", moderationStatus).Error
+		}
+		return nil
+	})
+	if err != nil {
+		removeFiles(store, uploaded)
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (u *produkUsecase) DeleteProdukPhoto(id, photoID, userID int, store storage.Storage) error {
+	if _, err := u.findOwnedProduk(id, userID); err != nil {
+		return err
+	}
+
+	photo, err := u.fotoProdukRepo.FindByID(photoID)
+	if err != nil || photo.IDProduk != id {
+		return errors.New("photo not found")
+	}
+
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&model.FotoProduk{}, photo.ID).Error; err != nil {
 			return err
 		}
 
+		// Promote the next photo when the cover is removed
+		if photo.IsCover {
+			var next model.FotoProduk
+			err := tx.Where("id_produk = ?", id).Order("posisi ASC, id ASC").First(&next).Error
+			if err == nil {
+				return tx.Model(&next).Update("is_cover", true).Error
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	removeFiles(store, photo.Files())
+	return nil
+}
+
+func (u *produkUsecase) ReorderProdukPhotos(id, userID int, photoIDs []int) error {
+	if _, err := u.findOwnedProduk(id, userID); err != nil {
+		return err
+	}
+
+	photos, err := u.fotoProdukRepo.FindByProdukID(id)
+	if err != nil {
+		return err
+	}
+
+	// The new order must list every photo of the product exactly once
+	owned := make(map[int]bool, len(photos))
+	for _, photo := range photos {
+		owned[photo.ID] = true
+	}
+	if len(photoIDs) != len(photos) {
+		return errors.New("photo_ids must contain all product photos")
+	}
+	seen := make(map[int]bool, len(photoIDs))
+	for _, photoID := range photoIDs {
+		if !owned[photoID] || seen[photoID] {
+			return errors.New("invalid photo id: " + strconv.Itoa(photoID))
+		}
+		seen[photoID] = true
+	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		for posisi, photoID := range photoIDs {
+			if err := tx.Model(&model.FotoProduk{}).Where("id = ?", photoID).Update("posisi", posisi).Error; err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
+
+func (u *produkUsecase) SetProdukCoverPhoto(id, photoID, userID int) error {
+	if _, err := u.findOwnedProduk(id, userID); err != nil {
+		return err
+	}
+
+	photo, err := u.fotoProdukRepo.FindByID(photoID)
+	if err != nil || photo.IDProduk != id {
+		return errors.New("photo not found")
+	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.FotoProduk{}).Where("id_produk = ?", id).Update("is_cover", false).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.FotoProduk{}).Where("id = ?", photo.ID).Update("is_cover", true).Error
+	})
+}
+
+// PublishProduk publishes a product now or schedules it for req.PublishAt
+func (u *produkUsecase) PublishProduk(id, userID int, req model.PublishProdukRequest) error {
+	produk, err := u.findOwnedProduk(id, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	status, publishAt, err := resolvePublishState(model.ProdukStatusPublished, req.PublishAt, now)
+	if err != nil {
+		return err
+	}
+
+	return updateProdukColumns(u.db, produk.ID, map[string]interface{}{
+		"status":     status,
+		"publish_at": publishAt,
+		"updated_at": now,
+	})
+}
+
+// UnpublishProduk moves a product back to draft without deleting it
+func (u *produkUsecase) UnpublishProduk(id, userID int) error {
+	produk, err := u.findOwnedProduk(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return updateProdukColumns(u.db, produk.ID, map[string]interface{}{
+		"status":     model.ProdukStatusDraft,
+		"publish_at": nil,
+		"updated_at": time.Now(),
+	})
+}
+
+// updateProdukColumns writes only the given columns of a product, a full
+// save of a row read earlier would restore its stock over concurrent checkouts
+func updateProdukColumns(db *gorm.DB, id int, columns map[string]interface{}) error {
+	return db.Model(&model.Produk{}).Where("id = ?", id).Updates(columns).Error
+}
+
+// resolvePublishState maps requested status and RFC3339 publish time to the
+// stored status, a future publish time makes the product scheduled
+func resolvePublishState(status, publishAt string, now time.Time) (string, *time.Time, error) {
+	if status == model.ProdukStatusDraft {
+		if publishAt != "" {
+			return "", nil, errors.New("draft products cannot have publish_at")
+		}
+		return model.ProdukStatusDraft, nil, nil
+	}
+
+	if publishAt == "" {
+		return model.ProdukStatusPublished, &now, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, publishAt)
+	if err != nil {
+		return "", nil, errors.New("invalid publish_at, use RFC3339 format")
+	}
+	if !at.After(now) {
+		return model.ProdukStatusPublished, &at, nil
+	}
+	return model.ProdukStatusScheduled, &at, nil
+}
+
+// moderationAfterEdit returns the moderation status of an edited product,
+// rejected products and those requiring approval go back to pending
+func (u *produkUsecase) moderationAfterEdit(produk *model.Produk, toko *model.Toko) string {
+	if produk.ModerationStatus == model.ModerationRejected || u.moderation.RequiresApproval(toko, produk.IDCategory) {
+		return model.ModerationPending
+	}
+	return produk.ModerationStatus
+}
+
+// findOwnedProduk finds produk and checks it belongs to the user's toko
+func (u *produkUsecase) findOwnedProduk(id, userID int) (*model.Produk, error) {
+	produk, err := u.produkRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return nil, errors.New("toko not found")
+	}
+	if toko.IDUser != userID {
+		return nil, errors.New("unauthorized: not your product")
+	}
+
+	return produk, nil
+}
+
+// removeFiles deletes uploaded files, failures are logged and ignored
+func removeFiles(store storage.Storage, urls []string) {
+	for _, url := range urls {
+		if err := utils.DeleteFile(store, url); err != nil {
+			log.Printf("Warning: failed to delete file %s: %v", url, err)
+		}
+	}
+}
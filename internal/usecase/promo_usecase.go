@@ -0,0 +1,240 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : promo_usecase.go
+// Description  : Business logic untuk flash sale dan harga promo terjadwal
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic jadwal harga promo per produk oleh penjual
+// - Harga promo harus lebih rendah dari harga konsumen
+// - Quota promo diklaim secara atomik di dalam transaksi CreateTrx
+// - Response produk menampilkan harga asli, harga promo, dan hitung mundur
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PromoUsecase interface
+type PromoUsecase interface {
+	GetProdukPromos(produkID, userID int) ([]model.ProdukPromo, error)
+	CreateProdukPromo(produkID, userID int, req model.CreateProdukPromoRequest) (int, error)
+	DeleteProdukPromo(produkID, promoID, userID int) error
+}
+
+type promoUsecase struct {
+	promoRepo  repository.ProdukPromoRepository
+	produkRepo repository.ProdukRepository
+	tokoRepo   repository.TokoRepository
+}
+
+// NewPromoUsecase creates new promo usecase
+func NewPromoUsecase(
+	promoRepo repository.ProdukPromoRepository,
+	produkRepo repository.ProdukRepository,
+	tokoRepo repository.TokoRepository,
+) PromoUsecase {
+	return &promoUsecase{
+		promoRepo:  promoRepo,
+		produkRepo: produkRepo,
+		tokoRepo:   tokoRepo,
+	}
+}
+
+// GetProdukPromos lists all promos of the user's product, past and upcoming
+func (u *promoUsecase) GetProdukPromos(produkID, userID int) ([]model.ProdukPromo, error) {
+	if _, err := u.findOwnedProduk(produkID, userID); err != nil {
+		return nil, err
+	}
+	return u.promoRepo.FindByProdukID(produkID)
+}
+
+func (u *promoUsecase) CreateProdukPromo(produkID, userID int, req model.CreateProdukPromoRequest) (int, error) {
+	produk, err := u.findOwnedProduk(produkID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	startAt, err := time.Parse(time.RFC3339, req.StartAt)
+	if err != nil {
+		return 0, errors.New("invalid start_at, use RFC3339 format")
+	}
+	endAt, err := time.Parse(time.RFC3339, req.EndAt)
+	if err != nil {
+		return 0, errors.New("invalid end_at, use RFC3339 format")
+	}
+	if !endAt.After(startAt) {
+		return 0, errors.New("end_at must be after start_at")
+	}
+
+	now := time.Now()
+	if !endAt.After(now) {
+		return 0, errors.New("end_at must be in the future")
+	}
+
+	hargaKonsumen, _ := strconv.Atoi(produk.HargaKonsumen)
+	if req.PromoPrice >= hargaKonsumen {
+		return 0, errors.New("promo_price must be lower than harga_konsumen")
+	}
+
+	overlapping, err := u.promoRepo.CountOverlapping(produkID, startAt, endAt)
+	if err != nil {
+		return 0, err
+	}
+	if overlapping > 0 {
+		return 0, errors.New("promo schedule overlaps another promo of this product")
+	}
+
+	promo := &model.ProdukPromo{
+		IDProduk:   produkID,
+		Label:      req.Label,
+		PromoPrice: req.PromoPrice,
+		StartAt:    startAt,
+		EndAt:      endAt,
+		Quota:      req.Quota,
+		CreatedAt:  &now,
+		UpdatedAt:  &now,
+	}
+	if err := u.promoRepo.Create(promo); err != nil {
+		return 0, err
+	}
+
+	return promo.ID, nil
+}
+
+// DeleteProdukPromo cancels a promo, transactions already made keep their
+// promo snapshot in LogProduk
+func (u *promoUsecase) DeleteProdukPromo(produkID, promoID, userID int) error {
+	if _, err := u.findOwnedProduk(produkID, userID); err != nil {
+		return err
+	}
+
+	promo, err := u.promoRepo.FindByID(promoID)
+	if err != nil || promo.IDProduk != produkID {
+		return errors.New("promo not found")
+	}
+
+	return u.promoRepo.Delete(promoID)
+}
+
+func (u *promoUsecase) findOwnedProduk(id, userID int) (*model.Produk, error) {
+	produk, err := u.produkRepo.FindByID(id)
+	if err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	toko, err := u.tokoRepo.FindByID(produk.IDToko)
+	if err != nil {
+		return nil, errors.New("toko not found")
+	}
+	if toko.IDUser != userID {
+		return nil, errors.New("unauthorized: not your product")
+	}
+
+	return produk, nil
+}
+
+// fillPromos sets the active promo of each product
+func fillPromos(promoRepo repository.ProdukPromoRepository, produks []model.Produk, now time.Time) {
+	ids := make([]int, 0, len(produks))
+	for _, produk := range produks {
+		ids = append(ids, produk.ID)
+	}
+
+	promos, err := promoRepo.FindActiveByProdukIDs(ids, now)
+	if err != nil {
+		return
+	}
+
+	byProduk := make(map[int]model.ProdukPromo, len(promos))
+	for _, promo := range promos {
+		byProduk[promo.IDProduk] = promo
+	}
+
+	for i := range produks {
+		if promo, ok := byProduk[produks[i].ID]; ok {
+			produks[i].Promo = promoPrice(produks[i], promo, now)
+		}
+	}
+}
+
+// promoPrice builds the promo view, nil when the promo is not cheaper
+func promoPrice(produk model.Produk, promo model.ProdukPromo, now time.Time) *model.PromoPrice {
+	original, _ := strconv.Atoi(produk.HargaKonsumen)
+	if promo.PromoPrice >= original {
+		return nil
+	}
+
+	price := &model.PromoPrice{
+		ID:              promo.ID,
+		Label:           promo.Label,
+		OriginalPrice:   original,
+		PromoPrice:      promo.PromoPrice,
+		DiscountPercent: (original - promo.PromoPrice) * 100 / original,
+		StartAt:         promo.StartAt,
+		EndAt:           promo.EndAt,
+		EndsInSeconds:   int64(promo.EndAt.Sub(now).Seconds()),
+	}
+	if promo.Quota > 0 {
+		remaining := promo.Quota - promo.Sold
+		price.RemainingQuota = &remaining
+	}
+	return price
+}
+
+// promoClaim checks a running promo against an order line, nil means the
+// promo is not cheaper and the regular price applies
+func promoClaim(produk *model.Produk, promo model.ProdukPromo, kuantitas int) (*model.ProdukPromo, error) {
+	original, _ := strconv.Atoi(produk.HargaKonsumen)
+	if promo.PromoPrice >= original {
+		return nil, nil
+	}
+
+	if promo.Quota > 0 && promo.Sold+kuantitas > promo.Quota {
+		return nil, fmt.Errorf("only %d left at promo price for product: %s", promo.Quota-promo.Sold, produk.NamaProduk)
+	}
+	return &promo, nil
+}
+
+// claimPromo reserves promo quota for kuantitas items of a product inside tx
+// and returns the claimed promo, nil when no cheaper promo is running
+func claimPromo(tx *gorm.DB, produk *model.Produk, kuantitas int, now time.Time) (*model.ProdukPromo, error) {
+	var promo model.ProdukPromo
+	err := tx.Where("id_produk = ? AND start_at <= ? AND end_at > ? AND (quota = 0 OR sold < quota)", produk.ID, now, now).
+		First(&promo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := promoClaim(produk, promo, kuantitas)
+	if claimed == nil || err != nil {
+		return nil, err
+	}
+
+	result := claimQuota(tx, model.ProdukPromo{}.TableName(), promo.ID, "sold", "quota", kuantitas)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, errors.New("promo quota exhausted for product: " + produk.NamaProduk)
+	}
+
+	promo.Sold += kuantitas
+	return &promo, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoClaim(t *testing.T) {
+	produk := &model.Produk{ID: 1, NamaProduk: "Kaos Polos", HargaKonsumen: "100000"}
+
+	tests := []struct {
+		name      string
+		promo     model.ProdukPromo
+		kuantitas int
+		claimed   bool
+		wantErr   string
+	}{
+		{"Unlimited quota", model.ProdukPromo{PromoPrice: 80000}, 50, true, ""},
+		{"Within quota", model.ProdukPromo{PromoPrice: 80000, Quota: 10, Sold: 3}, 2, true, ""},
+		{"Exact fill", model.ProdukPromo{PromoPrice: 80000, Quota: 10, Sold: 7}, 3, true, ""},
+		{"Over quota", model.ProdukPromo{PromoPrice: 80000, Quota: 10, Sold: 8}, 3, false, "only 2 left at promo price for product: Kaos Polos"},
+		{"Same as regular price", model.ProdukPromo{PromoPrice: 100000, Quota: 10}, 1, false, ""},
+		{"Above regular price", model.ProdukPromo{PromoPrice: 120000}, 1, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed, err := promoClaim(produk, tt.promo, tt.kuantitas)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.claimed, claimed != nil)
+		})
+	}
+}
+
+func TestPromoPrice(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	produk := model.Produk{HargaKonsumen: "100000"}
+	promo := model.ProdukPromo{
+		ID:         7,
+		Label:      "Flash Sale",
+		PromoPrice: 75000,
+		Quota:      10,
+		Sold:       4,
+		StartAt:    now.Add(-time.Hour),
+		EndAt:      now.Add(2 * time.Hour),
+	}
+
+	price := promoPrice(produk, promo, now)
+
+	assert.NotNil(t, price)
+	assert.Equal(t, 100000, price.OriginalPrice)
+	assert.Equal(t, 75000, price.PromoPrice)
+	assert.Equal(t, 25, price.DiscountPercent)
+	assert.Equal(t, int64(7200), price.EndsInSeconds)
+	assert.Equal(t, 6, *price.RemainingQuota)
+
+	promo.Quota = 0
+	assert.Nil(t, promoPrice(produk, promo, now).RemainingQuota)
+
+	promo.PromoPrice = 100000
+	assert.Nil(t, promoPrice(produk, promo, now))
+}
+
+func TestClaimQuota(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name     string
+		table    string
+		counter  string
+		quota    string
+		expected string
+	}{
+		{
+			name:     "Promo quota",
+			table:    "produk_promo",
+			counter:  "sold",
+			quota:    "quota",
+			expected: "UPDATE `produk_promo` SET `sold`=sold + ? WHERE id = ? AND (quota = 0 OR sold + ? <= quota)",
+		},
+		{
+			name:     "Pre-order quota",
+			table:    "produk",
+			counter:  "preorder_sold",
+			quota:    "preorder_quota",
+			expected: "UPDATE `produk` SET `preorder_sold`=preorder_sold + ? WHERE id = ? AND (preorder_quota = 0 OR preorder_sold + ? <= preorder_quota)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt := claimQuota(db, tt.table, 7, tt.counter, tt.quota, 3).Statement
+
+			assert.Equal(t, tt.expected, stmt.SQL.String())
+			assert.Equal(t, []interface{}{3, 7, 3}, stmt.Vars)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : recommendation_usecase.go
+// Description  : Business logic untuk rekomendasi produk terkait
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic "customers also bought" dan produk serupa
+// - Pasangan co-purchase dihitung ulang oleh job berkala (RefreshCoPurchases)
+// - Bila co-purchase kurang, diisi produk kategori sama lalu toko sama
+// - Hasil di-cache per produk selama CacheTTL, harga promo dihitung saat dibaca
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRelatedLimit = 10
+	maxRelatedLimit     = 50
+)
+
+// RecommendationUsecase interface
+type RecommendationUsecase interface {
+	GetRelatedProduk(id int, limit int) (*model.RelatedProducts, error)
+	RefreshCoPurchases() (int64, error)
+}
+
+type relatedCacheKey struct {
+	id    int
+	limit int
+}
+
+type relatedCacheEntry struct {
+	related   model.RelatedProducts
+	expiresAt time.Time
+}
+
+type recommendationUsecase struct {
+	recommendationRepo repository.RecommendationRepository
+	produkRepo         repository.ProdukRepository
+	promoRepo          repository.ProdukPromoRepository
+	lookbackDays       int
+	cacheTTL           time.Duration
+
+	mu    sync.RWMutex
+	cache map[relatedCacheKey]relatedCacheEntry
+}
+
+// NewRecommendationUsecase creates new recommendation usecase
+func NewRecommendationUsecase(
+	recommendationRepo repository.RecommendationRepository,
+	produkRepo repository.ProdukRepository,
+	promoRepo repository.ProdukPromoRepository,
+	lookbackDays int,
+	cacheTTL time.Duration,
+) RecommendationUsecase {
+	return &recommendationUsecase{
+		recommendationRepo: recommendationRepo,
+		produkRepo:         produkRepo,
+		promoRepo:          promoRepo,
+		lookbackDays:       lookbackDays,
+		cacheTTL:           cacheTTL,
+		cache:              make(map[relatedCacheKey]relatedCacheEntry),
+	}
+}
+
+// GetRelatedProduk returns products bought together with a product, topped up
+// with similar products up to limit
+func (u *recommendationUsecase) GetRelatedProduk(id int, limit int) (*model.RelatedProducts, error) {
+	if limit <= 0 {
+		limit = defaultRelatedLimit
+	}
+	if limit > maxRelatedLimit {
+		limit = maxRelatedLimit
+	}
+
+	key := relatedCacheKey{id: id, limit: limit}
+	related, ok := u.cached(key)
+	if !ok {
+		produk, err := u.produkRepo.FindByIDWithRelations(id, 0)
+		if err != nil {
+			return nil, errors.New("No Data Product")
+		}
+
+		if related, err = u.findRelated(produk, limit); err != nil {
+			return nil, err
+		}
+		u.store(key, related)
+	}
+
+	// Cached entries are shared, promos are filled on a copy since they
+	// depend on the current time
+	result := model.RelatedProducts{
+		AlsoBought: append([]model.Produk{}, related.AlsoBought...),
+		Similar:    append([]model.Produk{}, related.Similar...),
+	}
+	now := time.Now()
+	fillPromos(u.promoRepo, result.AlsoBought, now)
+	fillPromos(u.promoRepo, result.Similar, now)
+	return &result, nil
+}
+
+// RefreshCoPurchases recomputes the co-purchase pairs and drops the cache
+func (u *recommendationUsecase) RefreshCoPurchases() (int64, error) {
+	since := time.Now().AddDate(0, 0, -u.lookbackDays)
+	pairs, err := u.recommendationRepo.RefreshCoPurchases(since)
+	if err != nil {
+		return 0, err
+	}
+
+	u.mu.Lock()
+	u.cache = make(map[relatedCacheKey]relatedCacheEntry)
+	u.mu.Unlock()
+	return pairs, nil
+}
+
+func (u *recommendationUsecase) findRelated(produk *model.Produk, limit int) (model.RelatedProducts, error) {
+	related := model.RelatedProducts{AlsoBought: []model.Produk{}, Similar: []model.Produk{}}
+
+	alsoBought, err := u.recommendationRepo.FindCoPurchased(produk.ID, limit)
+	if err != nil {
+		return related, err
+	}
+	related.AlsoBought = inStock(alsoBought)
+
+	remaining := limit - len(related.AlsoBought)
+	if remaining <= 0 {
+		return related, nil
+	}
+
+	excludeIDs := make([]int, 0, len(alsoBought))
+	for _, item := range alsoBought {
+		excludeIDs = append(excludeIDs, item.ID)
+	}
+	similar, err := u.recommendationRepo.FindSimilar(produk, excludeIDs, remaining)
+	if err != nil {
+		return related, err
+	}
+	related.Similar = inStock(similar)
+	return related, nil
+}
+
+func (u *recommendationUsecase) cached(key relatedCacheKey) (model.RelatedProducts, bool) {
+	if u.cacheTTL <= 0 {
+		return model.RelatedProducts{}, false
+	}
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	entry, ok := u.cache[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return model.RelatedProducts{}, false
+	}
+	return entry.related, true
+}
+
+func (u *recommendationUsecase) store(key relatedCacheKey, related model.RelatedProducts) {
+	if u.cacheTTL <= 0 {
+		return
+	}
+
+	now := time.Now()
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	// Drop expired entries so the cache does not grow with every product viewed
+	for k, entry := range u.cache {
+		if now.After(entry.expiresAt) {
+			delete(u.cache, k)
+		}
+	}
+	u.cache[key] = relatedCacheEntry{related: related, expiresAt: now.Add(u.cacheTTL)}
+}
+
+// inStock drops bundles whose components ran out and exposes bundle stock,
+// digital products never run out
+func inStock(produks []model.Produk) []model.Produk {
+	fillBundleStock(produks)
+	available := make([]model.Produk, 0, len(produks))
+	for _, produk := range produks {
+		if produk.IsPreorder || produk.Type == model.ProdukTypeDigital || produk.Stok > 0 {
+			available = append(available, produk)
+		}
+	}
+	return available
+}
@@ -0,0 +1,230 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : review_usecase.go
+// Description  : Business logic untuk review dan rating produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic untuk membuat, membalas, dan moderasi review
+// - Review hanya bisa dibuat untuk baris DetailTrx milik pembeli
+// - Rata-rata dan jumlah rating produk/toko diperbarui secara incremental
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"evermos-api/internal/storage"
+	"evermos-api/internal/utils"
+	"mime/multipart"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReviewUsecase interface
+type ReviewUsecase interface {
+	GetReviewsByProdukID(produkID int, limit, offset int) (*model.PaginatedResponse, error)
+	CreateReview(userID int, req model.CreateReviewRequest, files []*multipart.FileHeader, store storage.Storage) (int, error)
+	ReplyReview(id, userID int, req model.ReplyReviewRequest) error
+	SetReviewHidden(id int, hidden bool) error
+}
+
+type reviewUsecase struct {
+	reviewRepo    repository.ReviewRepository
+	detailTrxRepo repository.DetailTrxRepository
+	tokoRepo      repository.TokoRepository
+	db            *gorm.DB
+}
+
+// NewReviewUsecase creates new review usecase
+func NewReviewUsecase(
+	reviewRepo repository.ReviewRepository,
+	detailTrxRepo repository.DetailTrxRepository,
+	tokoRepo repository.TokoRepository,
+	db *gorm.DB,
+) ReviewUsecase {
+	return &reviewUsecase{
+		reviewRepo:    reviewRepo,
+		detailTrxRepo: detailTrxRepo,
+		tokoRepo:      tokoRepo,
+		db:            db,
+	}
+}
+
+func (u *reviewUsecase) GetReviewsByProdukID(produkID int, limit, offset int) (*model.PaginatedResponse, error) {
+	reviews, err := u.reviewRepo.FindVisibleByProdukID(produkID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  reviews,
+	}, nil
+}
+
+func (u *reviewUsecase) CreateReview(userID int, req model.CreateReviewRequest, files []*multipart.FileHeader, store storage.Storage) (int, error) {
+	detail, err := u.detailTrxRepo.FindByIDWithRelations(req.DetailTrxID)
+	if err != nil || detail.Trx == nil || detail.LogProduk == nil {
+		return 0, errors.New("detail transaction not found")
+	}
+
+	// Only the buyer of this line can review it
+	if detail.Trx.IDUser != userID {
+		return 0, errors.New("unauthorized: not your transaction")
+	}
+
+	// Only completed purchases can be reviewed
+	if detail.Trx.StatusBayar != model.TrxStatusPaid {
+		return 0, errors.New("transaction has not been paid")
+	}
+
+	exists, err := u.reviewRepo.ExistsByDetailTrxID(detail.ID)
+	if err != nil {
+		return 0, errors.New("failed to check existing review")
+	}
+	if exists {
+		return 0, errors.New("this item has already been reviewed")
+	}
+
+	now := time.Now()
+	review := &model.Review{
+		IDDetailTrx: detail.ID,
+		IDProduk:    detail.LogProduk.IDProduk,
+		IDToko:      detail.IDToko,
+		IDUser:      userID,
+		Rating:      req.Rating,
+		Ulasan:      req.Ulasan,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+
+	var uploaded []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			urlFoto, err := utils.UploadFile(file, store, "review")
+			if err != nil {
+				return err
+			}
+			uploaded = append(uploaded, urlFoto)
+
+			foto := &model.FotoReview{
+				IDReview:  review.ID,
+				URL:       urlFoto,
+				CreatedAt: &now,
+			}
+			if err := tx.Create(foto).Error; err != nil {
+				return err
+			}
+		}
+
+		return applyRatingDelta(tx, review, 1)
+	})
+	if err != nil {
+		removeFiles(store, uploaded)
+		return 0, err
+	}
+
+	return review.ID, nil
+}
+
+func (u *reviewUsecase) ReplyReview(id, userID int, req model.ReplyReviewRequest) error {
+	review, err := u.reviewRepo.FindByID(id)
+	if err != nil {
+		return errors.New("review not found")
+	}
+
+	// Check ownership
+	toko, err := u.tokoRepo.FindByID(review.IDToko)
+	if err != nil {
+		return errors.New("toko not found")
+	}
+	if toko.IDUser != userID {
+		return errors.New("unauthorized: not your toko")
+	}
+
+	// The reply is only stored while there is none, so of two concurrent
+	// replies the second one is refused instead of overwriting the first
+	now := time.Now()
+	result := u.db.Model(&model.Review{}).
+		Where("id = ? AND (balasan IS NULL OR balasan = '')", review.ID).
+		Updates(map[string]interface{}{
+			"balasan":    req.Balasan,
+			"balasan_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("review has already been replied")
+	}
+	return nil
+}
+
+func (u *reviewUsecase) SetReviewHidden(id int, hidden bool) error {
+	review, err := u.reviewRepo.FindByID(id)
+	if err != nil {
+		return errors.New("review not found")
+	}
+
+	if review.IsHidden == hidden {
+		return nil
+	}
+
+	// Hidden reviews do not count towards the rating aggregates
+	sign := 1
+	if hidden {
+		sign = -1
+	}
+
+	// Only the flag is written so a concurrent reply is kept, and a
+	// concurrent toggle to the same state changes no row and no aggregate
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.Review{}).
+			Where("id = ? AND is_hidden = ?", review.ID, !hidden).
+			Updates(map[string]interface{}{
+				"is_hidden":  hidden,
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return applyRatingDelta(tx, review, sign)
+	})
+}
+
+// applyRatingDelta adds (sign=1) or removes (sign=-1) a review from the
+// produk and toko rating aggregates. MySQL evaluates single-table UPDATE
+// assignments left to right, so rating_avg sees the new total and count.
+func applyRatingDelta(tx *gorm.DB, review *model.Review, sign int) error {
+	for _, target := range []struct {
+		table string
+		id    int
+	}{
+		{table: "produk", id: review.IDProduk},
+		{table: "toko", id: review.IDToko},
+	} {
+		err := tx.Exec(
+			"UPDATE "+target.table+" SET rating_total = rating_total + ?, rating_count = rating_count + ?, "+
+				"rating_avg = IF(rating_count > 0, rating_total / rating_count, 0) WHERE id = ?",
+			sign*review.Rating, sign, target.id,
+		).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : slug.go
+// Description  : Helper slug unik dan redirect slug lama
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk generate slug unik produk dan toko
+// - Slug bentrok diberi suffix angka (-2, -3, ...)
+// - Slug produk unik per toko, slug toko unik global
+// - Slug lama dicatat di slug_redirect agar URL lama tetap bisa diakses
+// - Unique index slug menyelesaikan penulisan bersamaan, slug yang ternyata
+//   sudah dipakai dilewati dan slug berikutnya dicoba
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAmbiguousSlug is returned when a product slug exists in several toko
+var ErrAmbiguousSlug = errors.New("slug is used by several toko, specify toko")
+
+// maxSlugAttempts bounds the retries when concurrent writes keep taking the
+// chosen slug
+const maxSlugAttempts = 5
+
+// slugTable describes where slugs of an entity live and their uniqueness scope
+type slugTable struct {
+	table string
+	scope string
+	index string
+}
+
+var slugTables = map[string]slugTable{
+	model.SlugEntityProduk: {table: "produk", scope: "id_toko", index: model.SlugIndexProduk},
+	model.SlugEntityToko:   {table: "toko", index: model.SlugIndexToko},
+}
+
+// uniqueSlug generates a slug from name that is not used by another row in
+// the same scope. Old slugs still redirecting to other rows count as taken.
+func uniqueSlug(tx *gorm.DB, entity string, scopeID, selfID int, name string) (string, error) {
+	return freeSlug(tx, entity, scopeID, selfID, name, nil)
+}
+
+// saveWithSlug stores a row under a unique slug generated from name. A slug
+// taken by a concurrent write after it was picked fails on the unique index,
+// it is skipped and save runs again with the next free slug.
+func saveWithSlug(tx *gorm.DB, entity string, scopeID, selfID int, name string, save func(slug string) error) error {
+	skip := make(map[string]bool)
+	for attempt := 1; ; attempt++ {
+		slug, err := freeSlug(tx, entity, scopeID, selfID, name, skip)
+		if err != nil {
+			return err
+		}
+		err = save(slug)
+		if !isDuplicateSlug(err, entity) || attempt == maxSlugAttempts {
+			return err
+		}
+		skip[slug] = true
+	}
+}
+
+// isDuplicateSlug reports whether err is a duplicate key error on the slug
+// index of entity
+func isDuplicateSlug(err error, entity string) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 &&
+		strings.Contains(mysqlErr.Message, slugTables[entity].index)
+}
+
+// freeSlug finds the first slug from name not used in the scope, reserved nor
+// in skip, skip holds slugs a concurrent write took after this transaction's
+// snapshot
+func freeSlug(tx *gorm.DB, entity string, scopeID, selfID int, name string, skip map[string]bool) (string, error) {
+	base := utils.GenerateSlug(name)
+	t := slugTables[entity]
+
+	var taken []string
+	query := tx.Table(t.table).
+		Where("id <> ?", selfID).
+		Where("slug = ? OR slug LIKE ?", base, base+"-%")
+	if t.scope != "" {
+		query = query.Where(t.scope+" = ?", scopeID)
+	}
+	if err := query.Pluck("slug", &taken).Error; err != nil {
+		return "", err
+	}
+
+	var redirected []string
+	err := tx.Model(&model.SlugRedirect{}).
+		Where("entity = ? AND id_scope = ? AND id_target <> ?", entity, scopeID, selfID).
+		Where("slug = ? OR slug LIKE ?", base, base+"-%").
+		Pluck("slug", &redirected).Error
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool, len(taken)+len(redirected)+len(skip))
+	for _, s := range append(taken, redirected...) {
+		used[s] = true
+	}
+	for s := range skip {
+		used[s] = true
+	}
+	for s := range utils.ReservedSlugs {
+		used[s] = true
+	}
+
+	slug := base
+	for n := 2; used[slug]; n++ {
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+	return slug, nil
+}
+
+// recordSlugChange keeps oldSlug resolving to the renamed row
+func recordSlugChange(tx *gorm.DB, entity string, scopeID, id int, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	// The current slug must not be shadowed by its own redirect
+	err := tx.Where("entity = ? AND id_scope = ? AND slug = ?", entity, scopeID, newSlug).
+		Delete(&model.SlugRedirect{}).Error
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	redirect := &model.SlugRedirect{
+		Entity:    entity,
+		IDScope:   scopeID,
+		Slug:      oldSlug,
+		IDTarget:  id,
+		CreatedAt: &now,
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity"}, {Name: "id_scope"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"id_target"}),
+	}).Create(redirect).Error
+}
+
+// BackfillSlugs assigns unique slugs to toko and products without a slug or
+// sharing one (the oldest row keeps it), so the unique indexes can be created
+func BackfillSlugs(db *gorm.DB) error {
+	var tokos []model.Toko
+	err := db.Raw(`SELECT t.* FROM toko t
+		WHERE t.slug IS NULL OR t.slug = ''
+		OR EXISTS (SELECT 1 FROM toko s WHERE s.slug = t.slug AND s.id < t.id)
+		ORDER BY t.id`).
+		Scan(&tokos).Error
+	if err != nil {
+		return err
+	}
+	for _, toko := range tokos {
+		slug, err := uniqueSlug(db, model.SlugEntityToko, 0, toko.ID, toko.NamaToko)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&model.Toko{}).Where("id = ?", toko.ID).Update("slug", slug).Error; err != nil {
+			return err
+		}
+	}
+
+	var produks []model.Produk
+	err = db.Raw(`SELECT p.* FROM produk p
+		WHERE p.slug IS NULL OR p.slug = ''
+		OR EXISTS (SELECT 1 FROM produk q WHERE q.id_toko = p.id_toko AND q.slug = p.slug AND q.id < p.id)
+		ORDER BY p.id`).
+		Scan(&produks).Error
+	if err != nil {
+		return err
+	}
+	for _, produk := range produks {
+		slug, err := uniqueSlug(db, model.SlugEntityProduk, produk.IDToko, produk.ID, produk.NamaProduk)
+		if err != nil {
+			return err
+		}
+		if err := db.Model(&model.Produk{}).Where("id = ?", produk.ID).Update("slug", slug).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(tokos)+len(produks) > 0 {
+		log.Printf("Backfilled slugs for %d toko and %d produk", len(tokos), len(produks))
+	}
+	return nil
+}
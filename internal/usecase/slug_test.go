@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"evermos-api/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeSlug_Reserved(t *testing.T) {
+	// In dry run no row is returned, so only reserved and skipped slugs are used
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name     string
+		input    string
+		skip     map[string]bool
+		expected string
+	}{
+		{"Not Reserved", "Kaos Polos", nil, "kaos-polos"},
+		{"Reserved", "New", nil, "new-2"},
+		{"Reserved And Taken", "New", map[string]bool{"new-2": true}, "new-3"},
+		{"Taken", "Kaos", map[string]bool{"kaos": true}, "kaos-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slug, err := freeSlug(db, model.SlugEntityProduk, 1, 0, tt.input, tt.skip)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, slug)
+		})
+	}
+}
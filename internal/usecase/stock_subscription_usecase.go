@@ -0,0 +1,179 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : stock_subscription_usecase.go
+// Description  : Business logic untuk peringatan stok menipis dan langganan
+//                stok tersedia kembali
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic langganan notifikasi stok tersedia kembali
+// - Pemilik toko diberi notifikasi saat stok turun melewati low_stock_threshold
+// - Pelanggan diberi notifikasi saat stok naik dari nol, termasuk bundle yang
+//   kembali tersedia karena stok komponennya bertambah
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StockSubscriptionUsecase interface
+type StockSubscriptionUsecase interface {
+	Subscribe(produkID, userID int) error
+	Unsubscribe(produkID, userID int) error
+	GetMySubscriptions(userID int, limit, offset int) (*model.PaginatedResponse, error)
+}
+
+type stockSubscriptionUsecase struct {
+	subscriptionRepo repository.StockSubscriptionRepository
+	produkRepo       repository.ProdukRepository
+}
+
+// NewStockSubscriptionUsecase creates new stock subscription usecase
+func NewStockSubscriptionUsecase(
+	subscriptionRepo repository.StockSubscriptionRepository,
+	produkRepo repository.ProdukRepository,
+) StockSubscriptionUsecase {
+	return &stockSubscriptionUsecase{
+		subscriptionRepo: subscriptionRepo,
+		produkRepo:       produkRepo,
+	}
+}
+
+// Subscribe asks to be notified when an out of stock product is available again
+func (u *stockSubscriptionUsecase) Subscribe(produkID, userID int) error {
+	produk, err := u.produkRepo.FindByIDWithRelations(produkID, 0)
+	if err != nil {
+		return errors.New("product not found")
+	}
+	if produk.IsPreorder {
+		return errors.New("product is available for pre-order")
+	}
+	if produk.Type == model.ProdukTypeDigital {
+		return errors.New("digital products are always in stock")
+	}
+	if availableStock(*produk) > 0 {
+		return errors.New("product is in stock")
+	}
+
+	if _, err := u.subscriptionRepo.FindByUserAndProduk(userID, produkID); err == nil {
+		return nil
+	}
+
+	now := time.Now()
+	return u.subscriptionRepo.Create(&model.StockSubscription{
+		IDUser:    userID,
+		IDProduk:  produkID,
+		CreatedAt: &now,
+	})
+}
+
+func (u *stockSubscriptionUsecase) Unsubscribe(produkID, userID int) error {
+	subscription, err := u.subscriptionRepo.FindByUserAndProduk(userID, produkID)
+	if err != nil {
+		return errors.New("subscription not found")
+	}
+	return u.subscriptionRepo.Delete(subscription.ID)
+}
+
+func (u *stockSubscriptionUsecase) GetMySubscriptions(userID int, limit, offset int) (*model.PaginatedResponse, error) {
+	subscriptions, err := u.subscriptionRepo.FindByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  subscriptions,
+	}, nil
+}
+
+// checkLowStock notifies the toko owner inside tx when taking kuantitas moved
+// the stock of a product from above its low stock threshold to at or below it
+func checkLowStock(tx *gorm.DB, produkID, kuantitas int) error {
+	var produk model.Produk
+	if err := tx.Select("id", "id_toko", "nama_produk", "stok", "low_stock_threshold").First(&produk, produkID).Error; err != nil {
+		return err
+	}
+
+	threshold := produk.LowStockThreshold
+	if threshold <= 0 || produk.Stok > threshold || produk.Stok+kuantitas <= threshold {
+		return nil
+	}
+
+	var toko model.Toko
+	if err := tx.Select("id", "id_user").First(&toko, produk.IDToko).Error; err != nil {
+		return err
+	}
+
+	title := "Stok menipis"
+	message := fmt.Sprintf("Stok produk \"%s\" tersisa %d.", produk.NamaProduk, produk.Stok)
+	if produk.Stok == 0 {
+		title = "Stok habis"
+		message = fmt.Sprintf("Stok produk \"%s\" telah habis.", produk.NamaProduk)
+	}
+	return notify(tx, toko.IDUser, model.NotificationLowStock, title, message, produk.ID)
+}
+
+// notifyRestock is called inside tx after the stock of a product was raised
+// from oldStok. Subscribers are notified when the product was out of stock,
+// and for bundles using it that became available again.
+func notifyRestock(tx *gorm.DB, produk *model.Produk, oldStok int) error {
+	if produk.Stok <= oldStok {
+		return nil
+	}
+	if oldStok <= 0 {
+		if err := notifySubscribers(tx, produk); err != nil {
+			return err
+		}
+	}
+
+	var bundles []model.Produk
+	err := tx.Where("deleted_at IS NULL AND type = ?", model.ProdukTypeBundle).
+		Where("id IN (SELECT id_bundle FROM bundle_item WHERE id_produk = ?)", produk.ID).
+		Preload("BundleItems.Component").
+		Find(&bundles).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range bundles {
+		if bundleStock(bundles[i]) > 0 {
+			if err := notifySubscribers(tx, &bundles[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// notifySubscribers sends the back in stock notification once per subscriber
+// and ends their subscriptions
+func notifySubscribers(tx *gorm.DB, produk *model.Produk) error {
+	var userIDs []int
+	err := tx.Model(&model.StockSubscription{}).Where("id_produk = ?", produk.ID).Pluck("id_user", &userIDs).Error
+	if err != nil || len(userIDs) == 0 {
+		return err
+	}
+
+	err = notifyAll(tx, userIDs, model.NotificationBackInStock,
+		"Stok tersedia kembali",
+		fmt.Sprintf("Produk \"%s\" sudah tersedia kembali.", produk.NamaProduk),
+		produk.ID)
+	if err != nil {
+		return err
+	}
+	return tx.Where("id_produk = ?", produk.ID).Delete(&model.StockSubscription{}).Error
+}
@@ -0,0 +1,128 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : tag.go
+// Description  : Helper tag produk dan kategori sekunder
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk parsing dan penyimpanan tag produk
+// - Tag dinormalisasi (huruf kecil, spasi tunggal) dan disatukan berdasarkan slug
+// - Kategori sekunder disimpan terpisah dari kategori utama produk
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Limits for tags and secondary categories of a single product
+const (
+	maxProdukTags          = 20
+	maxTagLength           = 50
+	maxSecondaryCategories = 5
+)
+
+// normalizeTagName lowercases a tag and collapses its whitespace
+func normalizeTagName(name string) (string, error) {
+	name = strings.Join(strings.Fields(strings.ToLower(name)), " ")
+	if name == "" {
+		return "", errors.New("tag name cannot be empty")
+	}
+	if utf8.RuneCountInString(name) > maxTagLength {
+		return "", fmt.Errorf("tag %s must be at most %d characters", name, maxTagLength)
+	}
+	return name, nil
+}
+
+// parseTagNames splits a comma separated tag list, tags with the same slug
+// are only kept once
+func parseTagNames(raw string) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		name, err := normalizeTagName(part)
+		if err != nil {
+			return nil, err
+		}
+		slug := utils.GenerateSlug(name)
+		if seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		names = append(names, name)
+	}
+
+	if len(names) > maxProdukTags {
+		return nil, fmt.Errorf("a product can have at most %d tags", maxProdukTags)
+	}
+	return names, nil
+}
+
+// parseCategoryIDs splits a comma separated list of category IDs
+func parseCategoryIDs(raw string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid category ID: %s", part)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// replaceProdukTags swaps the tags of a product, creating missing tags
+func replaceProdukTags(tx *gorm.DB, produkID int, names []string) error {
+	if err := tx.Where("id_produk = ?", produkID).Delete(&model.ProdukTag{}).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, name := range names {
+		var tag model.Tag
+		err := tx.Where("slug = ?", utils.GenerateSlug(name)).
+			Attrs(model.Tag{Name: name, CreatedAt: &now, UpdatedAt: &now}).
+			FirstOrCreate(&tag).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&model.ProdukTag{IDProduk: produkID, IDTag: tag.ID}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceProdukCategories swaps the secondary categories of a product
+func replaceProdukCategories(tx *gorm.DB, produkID int, categoryIDs []int) error {
+	if err := tx.Where("id_produk = ?", produkID).Delete(&model.ProdukCategory{}).Error; err != nil {
+		return err
+	}
+	for _, categoryID := range categoryIDs {
+		if err := tx.Create(&model.ProdukCategory{IDProduk: produkID, IDCategory: categoryID}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
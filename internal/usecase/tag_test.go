@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"evermos-api/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProdukFilters_TagMatchesStoredSlug(t *testing.T) {
+	tests := []struct {
+		name   string
+		stored string
+		query  string
+	}{
+		{"ASCII", "Kaos Polos", " kaos  POLOS "},
+		{"Slug", "Kaos Polos", "kaos-polos"},
+		{"Fallback Emoji", "🔥", " 🔥"},
+		{"Fallback CJK", "東京 タワー", "東京   タワー "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names, err := parseTagNames(tt.stored)
+			require.NoError(t, err)
+			require.Len(t, names, 1)
+			slug := utils.GenerateSlug(names[0])
+
+			filters := parseProdukFilters(map[string]string{"tag": tt.query})
+			assert.Equal(t, []string{slug}, filters["tags"])
+		})
+	}
+}
+
+func TestParseProdukFilters_MultipleTags(t *testing.T) {
+	filters := parseProdukFilters(map[string]string{"tag": "kaos, ,🔥"})
+	assert.Equal(t, []string{"kaos", utils.GenerateSlug("🔥")}, filters["tags"])
+
+	filters = parseProdukFilters(map[string]string{"tag": " , "})
+	assert.NotContains(t, filters, "tags")
+}
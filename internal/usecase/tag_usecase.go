@@ -0,0 +1,121 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : tag_usecase.go
+// Description  : Business logic untuk tag produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic tag cloud dan pengelolaan tag oleh admin
+// - Rename tidak boleh menghasilkan slug milik tag lain, gunakan merge
+// - Merge memindahkan seluruh produk ke tag tujuan lalu menghapus tag asal
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"evermos-api/internal/utils"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultTagCloudLimit is used when no limit is requested
+const defaultTagCloudLimit = 50
+
+// TagUsecase interface
+type TagUsecase interface {
+	GetTagCloud(limit int) ([]model.TagCount, error)
+	RenameTag(id int, req model.RenameTagRequest) error
+	MergeTag(id int, req model.MergeTagRequest) error
+	DeleteTag(id int) error
+}
+
+type tagUsecase struct {
+	tagRepo repository.TagRepository
+	db      *gorm.DB
+}
+
+// NewTagUsecase creates new tag usecase
+func NewTagUsecase(tagRepo repository.TagRepository, db *gorm.DB) TagUsecase {
+	return &tagUsecase{tagRepo: tagRepo, db: db}
+}
+
+// GetTagCloud returns the most used tags with their product counts
+func (u *tagUsecase) GetTagCloud(limit int) ([]model.TagCount, error) {
+	if limit <= 0 {
+		limit = defaultTagCloudLimit
+	}
+	return u.tagRepo.FindCounts(limit)
+}
+
+func (u *tagUsecase) RenameTag(id int, req model.RenameTagRequest) error {
+	tag, err := u.tagRepo.FindByID(id)
+	if err != nil {
+		return errors.New("tag not found")
+	}
+
+	name, err := normalizeTagName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	slug := utils.GenerateSlug(name)
+	if existing, err := u.tagRepo.FindBySlug(slug); err == nil && existing.ID != tag.ID {
+		return errors.New("tag with this name already exists, merge the tags instead")
+	}
+
+	tag.Name = name
+	tag.Slug = slug
+	now := time.Now()
+	tag.UpdatedAt = &now
+
+	return u.tagRepo.Update(tag)
+}
+
+// MergeTag moves all products of tag id to the target tag and removes tag id
+func (u *tagUsecase) MergeTag(id int, req model.MergeTagRequest) error {
+	if id == req.TargetID {
+		return errors.New("cannot merge a tag into itself")
+	}
+	if _, err := u.tagRepo.FindByID(id); err != nil {
+		return errors.New("tag not found")
+	}
+	if _, err := u.tagRepo.FindByID(req.TargetID); err != nil {
+		return errors.New("target tag not found")
+	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		// Products already tagged with the target keep a single row
+		err := tx.Exec(
+			"INSERT IGNORE INTO produk_tag (id_produk, id_tag) SELECT id_produk, ? FROM produk_tag WHERE id_tag = ?",
+			req.TargetID, id,
+		).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("id_tag = ?", id).Delete(&model.ProdukTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Tag{}, id).Error
+	})
+}
+
+func (u *tagUsecase) DeleteTag(id int) error {
+	if _, err := u.tagRepo.FindByID(id); err != nil {
+		return errors.New("tag not found")
+	}
+
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id_tag = ?", id).Delete(&model.ProdukTag{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.Tag{}, id).Error
+	})
+}
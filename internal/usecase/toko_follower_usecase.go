@@ -0,0 +1,166 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : toko_follower_usecase.go
+// Description  : Business logic untuk follow toko dan feed produk
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic follow, unfollow, daftar toko yang diikuti, dan feed
+// - follower_count toko diperbarui dalam transaksi yang sama dengan follow
+// - Feed berisi produk terbaru dari toko yang diikuti dengan cursor pagination
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"evermos-api/internal/utils"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokoFollowerUsecase interface
+type TokoFollowerUsecase interface {
+	FollowToko(tokoID, userID int) error
+	UnfollowToko(tokoID, userID int) error
+	GetFollowing(userID int, limit, offset int) (*model.PaginatedResponse, error)
+	GetFeed(userID int, limit int, cursor string) (*model.PaginatedResponse, error)
+}
+
+type tokoFollowerUsecase struct {
+	followerRepo repository.TokoFollowerRepository
+	tokoRepo     repository.TokoRepository
+	promoRepo    repository.ProdukPromoRepository
+	wishlistRepo repository.WishlistRepository
+	db           *gorm.DB
+}
+
+// NewTokoFollowerUsecase creates new toko follower usecase
+func NewTokoFollowerUsecase(
+	followerRepo repository.TokoFollowerRepository,
+	tokoRepo repository.TokoRepository,
+	promoRepo repository.ProdukPromoRepository,
+	wishlistRepo repository.WishlistRepository,
+	db *gorm.DB,
+) TokoFollowerUsecase {
+	return &tokoFollowerUsecase{
+		followerRepo: followerRepo,
+		tokoRepo:     tokoRepo,
+		promoRepo:    promoRepo,
+		wishlistRepo: wishlistRepo,
+		db:           db,
+	}
+}
+
+// FollowToko follows a toko, following it again is a no-op
+func (u *tokoFollowerUsecase) FollowToko(tokoID, userID int) error {
+	toko, err := u.tokoRepo.FindByID(tokoID)
+	if err != nil {
+		return errors.New("toko not found")
+	}
+	if toko.IDUser == userID {
+		return errors.New("you cannot follow your own toko")
+	}
+
+	now := time.Now()
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		// The unique index settles concurrent follows, only a new row counts
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.TokoFollower{
+			IDUser:    userID,
+			IDToko:    tokoID,
+			CreatedAt: &now,
+		})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		// follower_count is create-only on the model, so the table is named
+		return tx.Table(model.Toko{}.TableName()).Where("id = ?", tokoID).
+			UpdateColumn("follower_count", gorm.Expr("follower_count + 1")).Error
+	})
+}
+
+func (u *tokoFollowerUsecase) UnfollowToko(tokoID, userID int) error {
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id_user = ? AND id_toko = ?", userID, tokoID).Delete(&model.TokoFollower{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("you are not following this toko")
+		}
+		return tx.Table(model.Toko{}.TableName()).Where("id = ? AND follower_count > 0", tokoID).
+			UpdateColumn("follower_count", gorm.Expr("follower_count - 1")).Error
+	})
+}
+
+func (u *tokoFollowerUsecase) GetFollowing(userID int, limit, offset int) (*model.PaginatedResponse, error) {
+	followers, err := u.followerRepo.FindByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	followed := true
+	tokoResponses := make([]model.TokoResponse, 0, len(followers))
+	for _, follower := range followers {
+		if follower.Toko == nil {
+			continue
+		}
+		response := newTokoResponse(follower.Toko)
+		response.IsFollowed = &followed
+		tokoResponses = append(tokoResponses, response)
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  tokoResponses,
+	}, nil
+}
+
+// GetFeed lists the newest products of the tokos followed by the user
+func (u *tokoFollowerUsecase) GetFeed(userID int, limit int, cursor string) (*model.PaginatedResponse, error) {
+	decoded, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	produks, err := u.followerRepo.FindFeedByCursor(userID, limit, decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	produks, next, prev := utils.CursorPage(produks, limit, decoded, func(p model.Produk) (string, int) {
+		return strconv.FormatInt(p.PublishAt.Unix(), 10), p.ID
+	})
+
+	fillPromos(u.promoRepo, produks, time.Now())
+	fillBundleStock(produks)
+	fillWishlisted(u.wishlistRepo, userID, produks)
+
+	return &model.PaginatedResponse{
+		Limit:      limit,
+		NextCursor: next,
+		PrevCursor: prev,
+		Data:       produks,
+	}, nil
+}
+
+// isFollowing reports whether the user follows a toko, anonymous users
+// follow nothing
+func isFollowing(followerRepo repository.TokoFollowerRepository, userID, tokoID int) *bool {
+	if userID <= 0 {
+		return nil
+	}
+	_, err := followerRepo.FindByUserAndToko(userID, tokoID)
+	following := err == nil
+	return &following
+}
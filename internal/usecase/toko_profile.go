@@ -0,0 +1,243 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : toko_profile.go
+// Description  : Helper profil toko (alamat, kontak, jam buka, mode libur)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi helper untuk memvalidasi dan menerapkan profil toko
+// - Provinsi dan kota divalidasi ke API wilayah, namanya disimpan agar respons
+//   toko tidak perlu memanggil API eksternal
+// - Jam buka berupa JSON array {day, open, close} dengan format HH:MM
+// - Toko yang sedang libur menolak transaksi dengan pesan yang jelas
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/utils"
+	"fmt"
+	"net/mail"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// applyTokoProfile applies the profile fields of req to toko, omitted fields
+// are kept and empty values clear them
+func (u *tokoUsecase) applyTokoProfile(toko *model.Toko, req model.UpdateTokoRequest, now time.Time) error {
+	if req.Deskripsi != nil {
+		toko.Deskripsi = strings.TrimSpace(*req.Deskripsi)
+	}
+	if req.Alamat != nil {
+		toko.Alamat = strings.TrimSpace(*req.Alamat)
+	}
+	if req.NoTelp != nil {
+		toko.NoTelp = strings.TrimSpace(*req.NoTelp)
+	}
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if email != "" {
+			if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+				return errors.New("invalid email")
+			}
+		}
+		toko.Email = email
+	}
+
+	if err := u.applyTokoWilayah(toko, req.IDProvinsi, req.IDKota); err != nil {
+		return err
+	}
+
+	if req.OpeningHours != nil {
+		hours, err := parseOpeningHours(*req.OpeningHours)
+		if err != nil {
+			return err
+		}
+		toko.OpeningHours = hours
+	}
+
+	return applyVacation(toko, req, now)
+}
+
+// applyTokoWilayah sets the province and city of the toko address, the city
+// must belong to the province and a new province clears the city
+func (u *tokoUsecase) applyTokoWilayah(toko *model.Toko, provinsiID, kotaID *string) error {
+	if provinsiID != nil {
+		raw := strings.TrimSpace(*provinsiID)
+		if raw == "" {
+			toko.IDProvinsi, toko.NamaProvinsi = nil, ""
+			toko.IDKota, toko.NamaKota = nil, ""
+		} else {
+			id, err := strconv.Atoi(raw)
+			if err != nil {
+				return errors.New("invalid id_provinsi")
+			}
+			if toko.IDProvinsi == nil || *toko.IDProvinsi != id {
+				provinsi, err := u.wilayahUsecase.GetDetailProvince(strconv.Itoa(id))
+				if err != nil {
+					return err
+				}
+				toko.IDProvinsi, toko.NamaProvinsi = &id, provinsi.Name
+				toko.IDKota, toko.NamaKota = nil, ""
+			}
+		}
+	}
+
+	if kotaID != nil {
+		raw := strings.TrimSpace(*kotaID)
+		if raw == "" {
+			toko.IDKota, toko.NamaKota = nil, ""
+			return nil
+		}
+		if toko.IDProvinsi == nil {
+			return errors.New("id_provinsi is required to set id_kota")
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("invalid id_kota")
+		}
+		kota, err := u.wilayahUsecase.GetDetailCity(strconv.Itoa(id))
+		if err != nil {
+			return err
+		}
+		if kota.ProvinceID != strconv.Itoa(*toko.IDProvinsi) {
+			return errors.New("city is not in the selected province")
+		}
+		toko.IDKota, toko.NamaKota = &id, kota.Name
+	}
+	return nil
+}
+
+// parseOpeningHours decodes the opening_hours form field, a JSON array of
+// {"day", "open", "close"}, sorted from monday to sunday
+func parseOpeningHours(raw string) ([]model.OpeningHour, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var hours []model.OpeningHour
+	if err := json.Unmarshal([]byte(raw), &hours); err != nil {
+		return nil, errors.New("opening_hours must be a JSON array")
+	}
+
+	order := make(map[string]int, len(model.OpeningDays))
+	for i, day := range model.OpeningDays {
+		order[day] = i
+	}
+
+	seen := make(map[string]bool, len(hours))
+	for i := range hours {
+		day := strings.ToLower(strings.TrimSpace(hours[i].Day))
+		if _, ok := order[day]; !ok {
+			return nil, fmt.Errorf("invalid opening_hours day: %s", hours[i].Day)
+		}
+		if seen[day] {
+			return nil, fmt.Errorf("opening_hours has %s more than once", day)
+		}
+		seen[day] = true
+
+		open, openErr := time.Parse("15:04", hours[i].Open)
+		closing, closeErr := time.Parse("15:04", hours[i].Close)
+		if openErr != nil || closeErr != nil {
+			return nil, fmt.Errorf("invalid opening_hours time on %s, use HH:MM format", day)
+		}
+		if !open.Before(closing) {
+			return nil, fmt.Errorf("opening_hours on %s must open before it closes", day)
+		}
+		hours[i] = model.OpeningHour{Day: day, Open: open.Format("15:04"), Close: closing.Format("15:04")}
+	}
+
+	sort.Slice(hours, func(a, b int) bool {
+		return order[hours[a].Day] < order[hours[b].Day]
+	})
+	return hours, nil
+}
+
+// applyVacation updates the vacation mode, vacation_until is the date the
+// toko takes orders again
+func applyVacation(toko *model.Toko, req model.UpdateTokoRequest, now time.Time) error {
+	if req.IsOnVacation != nil {
+		toko.IsVacation = *req.IsOnVacation
+		if !toko.IsVacation {
+			toko.VacationUntil = nil
+		}
+	}
+	if req.VacationMessage != nil {
+		toko.VacationMessage = strings.TrimSpace(*req.VacationMessage)
+	}
+	if req.VacationUntil != nil {
+		if *req.VacationUntil == "" {
+			toko.VacationUntil = nil
+			return nil
+		}
+		until, err := time.ParseInLocation("2006-01-02", *req.VacationUntil, time.Local)
+		if err != nil {
+			return errors.New("invalid vacation_until, use YYYY-MM-DD format")
+		}
+		if !until.After(now) {
+			return errors.New("vacation_until must be a future date")
+		}
+		toko.VacationUntil = &until
+	}
+	return nil
+}
+
+// saveWithCustomTokoSlug saves the toko under a slug chosen by the seller, it
+// must already be in slug form and not used or redirected by another toko
+func saveWithCustomTokoSlug(tx *gorm.DB, toko *model.Toko, requested string) error {
+	if utils.GenerateSlug(requested) != requested {
+		return errors.New("invalid slug, use lowercase letters, numbers and hyphens")
+	}
+
+	slug, err := uniqueSlug(tx, model.SlugEntityToko, 0, toko.ID, requested)
+	if err != nil {
+		return err
+	}
+	if slug != requested {
+		return errors.New("slug is already taken")
+	}
+
+	// A concurrent write may still take it before this save
+	toko.Slug = slug
+	err = tx.Omit("trust_level").Save(toko).Error
+	if isDuplicateSlug(err, model.SlugEntityToko) {
+		return errors.New("slug is already taken")
+	}
+	return err
+}
+
+// vacationError explains why products of a toko on vacation cannot be ordered
+func vacationError(toko *model.Toko, namaProduk string) error {
+	msg := fmt.Sprintf("toko %s is on vacation", toko.NamaToko)
+	if toko.VacationUntil != nil {
+		msg += " until " + toko.VacationUntil.Format("2006-01-02")
+	}
+	msg += ", product " + namaProduk + " cannot be ordered right now"
+	if toko.VacationMessage != "" {
+		msg += ": " + toko.VacationMessage
+	}
+	return errors.New(msg)
+}
+
+// tokoImageFiles returns the stored files of a toko image with its thumbnails
+func tokoImageFiles(url string, sizes map[string]string) []string {
+	if url == "" {
+		return nil
+	}
+	files := []string{url}
+	for _, thumb := range sizes {
+		files = append(files, thumb)
+	}
+	return files
+}
@@ -11,6 +11,8 @@
 // - File ini berisi logic untuk CRUD toko
 // - Menangani upload foto toko
 // - Validasi kepemilikan toko
+// - Profil toko (deskripsi, banner, alamat, kontak, jam buka, mode libur) dan
+//   slug pilihan penjual diperbarui bersama nama dan foto
 //
 // ============================================================================
 
@@ -20,26 +22,48 @@ import (
 	"errors"
 	"evermos-api/internal/model"
 	"evermos-api/internal/repository"
+	"evermos-api/internal/storage"
 	"evermos-api/internal/utils"
 	"mime/multipart"
+	"strconv"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // TokoUsecase interface
 type TokoUsecase interface {
 	GetMyToko(userID int) (*model.TokoResponse, error)
-	GetTokoByID(id int) (*model.TokoResponse, error)
+	GetTokoByID(id, userID int) (*model.TokoResponse, error)
+	GetTokoBySlug(slug string, userID int) (*model.TokoResponse, error)
 	GetAllToko(limit, offset int, nama string) (*model.PaginatedResponse, error)
-	UpdateToko(tokoID, userID int, namaToko string, file *multipart.FileHeader, uploadPath string) error
+	GetAllTokoByCursor(limit int, cursor string, nama string) (*model.PaginatedResponse, error)
+	UpdateToko(tokoID, userID int, req model.UpdateTokoRequest, photo, banner *multipart.FileHeader, store storage.Storage) error
 }
 
 type tokoUsecase struct {
-	tokoRepo repository.TokoRepository
+	tokoRepo       repository.TokoRepository
+	followerRepo   repository.TokoFollowerRepository
+	slugRepo       repository.SlugRedirectRepository
+	wilayahUsecase WilayahUsecase
+	db             *gorm.DB
 }
 
 // NewTokoUsecase creates new toko usecase
-func NewTokoUsecase(tokoRepo repository.TokoRepository) TokoUsecase {
-	return &tokoUsecase{tokoRepo: tokoRepo}
+func NewTokoUsecase(
+	tokoRepo repository.TokoRepository,
+	followerRepo repository.TokoFollowerRepository,
+	slugRepo repository.SlugRedirectRepository,
+	wilayahUsecase WilayahUsecase,
+	db *gorm.DB,
+) TokoUsecase {
+	return &tokoUsecase{
+		tokoRepo:       tokoRepo,
+		followerRepo:   followerRepo,
+		slugRepo:       slugRepo,
+		wilayahUsecase: wilayahUsecase,
+		db:             db,
+	}
 }
 
 func (u *tokoUsecase) GetMyToko(userID int) (*model.TokoResponse, error) {
@@ -48,25 +72,38 @@ func (u *tokoUsecase) GetMyToko(userID int) (*model.TokoResponse, error) {
 		return nil, errors.New("toko not found")
 	}
 
-	return &model.TokoResponse{
-		ID:       toko.ID,
-		NamaToko: toko.NamaToko,
-		URLFoto:  toko.URLFoto,
-		UserID:   toko.IDUser,
-	}, nil
+	response := newTokoResponse(toko)
+	response.UserID = toko.IDUser
+	return &response, nil
 }
 
-func (u *tokoUsecase) GetTokoByID(id int) (*model.TokoResponse, error) {
+func (u *tokoUsecase) GetTokoByID(id, userID int) (*model.TokoResponse, error) {
 	toko, err := u.tokoRepo.FindByID(id)
 	if err != nil {
 		return nil, errors.New("`Toko tidak ditemukan`")
 	}
 
-	return &model.TokoResponse{
-		ID:       toko.ID,
-		NamaToko: toko.NamaToko,
-		URLFoto:  toko.URLFoto,
-	}, nil
+	response := newTokoResponse(toko)
+	response.IsFollowed = isFollowing(u.followerRepo, userID, toko.ID)
+	return &response, nil
+}
+
+// GetTokoBySlug resolves a toko by its current or former slug
+func (u *tokoUsecase) GetTokoBySlug(slug string, userID int) (*model.TokoResponse, error) {
+	toko, err := u.tokoRepo.FindBySlug(slug)
+	if err != nil {
+		redirect, redirectErr := u.slugRepo.FindBySlug(model.SlugEntityToko, 0, slug)
+		if redirectErr != nil {
+			return nil, errors.New("toko not found")
+		}
+		if toko, err = u.tokoRepo.FindByID(redirect.IDTarget); err != nil {
+			return nil, errors.New("toko not found")
+		}
+	}
+
+	response := newTokoResponse(toko)
+	response.IsFollowed = isFollowing(u.followerRepo, userID, toko.ID)
+	return &response, nil
 }
 
 func (u *tokoUsecase) GetAllToko(limit, offset int, nama string) (*model.PaginatedResponse, error) {
@@ -76,12 +113,8 @@ func (u *tokoUsecase) GetAllToko(limit, offset int, nama string) (*model.Paginat
 	}
 
 	var tokoResponses []model.TokoResponse
-	for _, toko := range tokos {
-		tokoResponses = append(tokoResponses, model.TokoResponse{
-			ID:       toko.ID,
-			NamaToko: toko.NamaToko,
-			URLFoto:  toko.URLFoto,
-		})
+	for i := range tokos {
+		tokoResponses = append(tokoResponses, newTokoResponse(&tokos[i]))
 	}
 
 	return &model.PaginatedResponse{
@@ -91,7 +124,37 @@ func (u *tokoUsecase) GetAllToko(limit, offset int, nama string) (*model.Paginat
 	}, nil
 }
 
-func (u *tokoUsecase) UpdateToko(tokoID, userID int, namaToko string, file *multipart.FileHeader, uploadPath string) error {
+func (u *tokoUsecase) GetAllTokoByCursor(limit int, cursor string, nama string) (*model.PaginatedResponse, error) {
+	decoded, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	tokos, err := u.tokoRepo.FindAllByCursor(limit, decoded, nama)
+	if err != nil {
+		return nil, err
+	}
+
+	tokos, next, prev := utils.CursorPage(tokos, limit, decoded, func(t model.Toko) (string, int) {
+		return "", t.ID
+	})
+
+	var tokoResponses []model.TokoResponse
+	for i := range tokos {
+		tokoResponses = append(tokoResponses, newTokoResponse(&tokos[i]))
+	}
+
+	return &model.PaginatedResponse{
+		Limit:      limit,
+		NextCursor: next,
+		PrevCursor: prev,
+		Data:       tokoResponses,
+	}, nil
+}
+
+// UpdateToko updates the toko profile, a new photo or banner replaces the old
+// image once the change is saved
+func (u *tokoUsecase) UpdateToko(tokoID, userID int, req model.UpdateTokoRequest, photo, banner *multipart.FileHeader, store storage.Storage) error {
 	toko, err := u.tokoRepo.FindByID(tokoID)
 	if err != nil {
 		return errors.New("toko not found")
@@ -103,32 +166,106 @@ func (u *tokoUsecase) UpdateToko(tokoID, userID int, namaToko string, file *mult
 	}
 
 	// Update nama toko if provided
-	if namaToko != "" {
-		toko.NamaToko = namaToko
+	renamed := req.NamaToko != "" && req.NamaToko != toko.NamaToko
+	if req.NamaToko != "" {
+		toko.NamaToko = req.NamaToko
 	}
+	reslugged := req.Slug != "" && req.Slug != toko.Slug
 
-	// Handle file upload if provided
-	if file != nil {
-		// In production, you would use the upload utility here
-		// For now, just set a placeholder
-		// toko.URLFoto = file.Filename
+	now := time.Now()
+	if err := u.applyTokoProfile(toko, req, now); err != nil {
+		return err
+	}
+	toko.UpdatedAt = &now
 
-		// Upload file using utility
-		urlFoto, err := utils.UploadFile(file, uploadPath, "toko")
-		if err != nil {
-			return err
+	var uploaded, replaced []string
+	err = u.db.Transaction(func(tx *gorm.DB) error {
+		if photo != nil {
+			img, err := utils.UploadImage(photo, store, "toko", utils.ImageProcessing.ThumbnailSizes)
+			if err != nil {
+				return err
+			}
+			uploaded = append(uploaded, tokoImageFiles(img.URL, img.Sizes)...)
+			replaced = append(replaced, tokoImageFiles(toko.URLFoto, toko.URLFotoSizes)...)
+			toko.URLFoto = img.URL
+			toko.URLFotoSizes = img.Sizes
 		}
 
-		// Delete old photo if exists
-		if toko.URLFoto != "" {
-			_ = utils.DeleteFile(uploadPath, toko.URLFoto)
+		if banner != nil {
+			img, err := utils.UploadImage(banner, store, "toko", utils.ImageProcessing.ThumbnailSizes)
+			if err != nil {
+				return err
+			}
+			uploaded = append(uploaded, tokoImageFiles(img.URL, img.Sizes)...)
+			replaced = append(replaced, tokoImageFiles(toko.URLBanner, toko.URLBannerSizes)...)
+			toko.URLBanner = img.URL
+			toko.URLBannerSizes = img.Sizes
 		}
 
-		toko.URLFoto = urlFoto
+		// A chosen slug wins over regenerating it on rename, the old one
+		// keeps redirecting here. trust_level is set by admins only and is
+		// never written back from this read.
+		oldSlug := toko.Slug
+		var err error
+		switch {
+		case reslugged:
+			err = saveWithCustomTokoSlug(tx, toko, req.Slug)
+		case renamed && req.Slug == "":
+			err = saveWithSlug(tx, model.SlugEntityToko, 0, toko.ID, toko.NamaToko, func(slug string) error {
+				toko.Slug = slug
+				return tx.Omit("trust_level").Save(toko).Error
+			})
+		default:
+			err = tx.Omit("trust_level").Save(toko).Error
+		}
+		if err != nil {
+			return err
+		}
+		return recordSlugChange(tx, model.SlugEntityToko, 0, toko.ID, oldSlug, toko.Slug)
+	})
+	if err != nil {
+		removeFiles(store, uploaded)
+		return err
 	}
 
-	now := time.Now()
-	toko.UpdatedAt = &now
+	// Old images are only removed once the new ones are saved
+	removeFiles(store, replaced)
+	return nil
+}
 
-	return u.tokoRepo.Update(toko)
+// newTokoResponse maps toko entity to its public response
+func newTokoResponse(toko *model.Toko) model.TokoResponse {
+	response := model.TokoResponse{
+		ID:             toko.ID,
+		NamaToko:       toko.NamaToko,
+		Slug:           toko.Slug,
+		URLFoto:        utils.MediaURL(toko.URLFoto),
+		URLFotoSizes:   utils.MediaURLs(toko.URLFotoSizes),
+		RatingAvg:      toko.RatingAvg,
+		RatingCount:    toko.RatingCount,
+		FollowerCount:  toko.FollowerCount,
+		Deskripsi:      toko.Deskripsi,
+		URLBanner:      utils.MediaURL(toko.URLBanner),
+		URLBannerSizes: utils.MediaURLs(toko.URLBannerSizes),
+		Alamat:         toko.Alamat,
+		NoTelp:         toko.NoTelp,
+		Email:          toko.Email,
+		OpeningHours:   toko.OpeningHours,
+		IsOnVacation:   toko.OnVacation(time.Now()),
+	}
+
+	if toko.IDProvinsi != nil {
+		response.Provinsi = &model.Province{ID: strconv.Itoa(*toko.IDProvinsi), Name: toko.NamaProvinsi}
+	}
+	if toko.IDKota != nil {
+		response.Kota = &model.City{ID: strconv.Itoa(*toko.IDKota), Name: toko.NamaKota}
+		if response.Provinsi != nil {
+			response.Kota.ProvinceID = response.Provinsi.ID
+		}
+	}
+	if response.IsOnVacation {
+		response.VacationMessage = toko.VacationMessage
+		response.VacationUntil = toko.VacationUntil
+	}
+	return response
 }
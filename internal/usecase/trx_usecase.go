@@ -11,6 +11,16 @@
 // - File ini berisi logic untuk membuat dan mendapatkan transaksi
 // - Generate invoice otomatis dengan format INV-YYYYMMDD-XXXX
 // - Membuat snapshot produk dalam log_produk
+// - Harga promo aktif diterapkan dan quota promo diklaim dalam satu transaksi
+// - Produk bundle mengurangi stok setiap komponennya
+// - Pemilik toko diberi notifikasi saat stok turun melewati batas minimum
+// - Produk pre-order tidak memakai stok, quota pre-order diklaim dan estimasi
+//   tanggal kirim dicatat di DetailTrx
+// - Produk digital tidak memakai stok maupun alamat pengiriman, pembeli diberi
+//   hak unduh (DownloadGrant) untuk file produk yang aktif setelah dibayar
+// - Transaksi dibuat unpaid, konfirmasi pembayaran menandainya paid dan
+//   mengaktifkan hak unduh
+// - Produk dari toko yang sedang libur ditolak dengan pesan dari toko
 //
 // ============================================================================
 
@@ -21,6 +31,7 @@ import (
 	"evermos-api/internal/model"
 	"evermos-api/internal/repository"
 	"evermos-api/internal/utils"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -30,8 +41,10 @@ import (
 // TrxUsecase interface
 type TrxUsecase interface {
 	GetAllTrx(userID int, limit, offset int) ([]model.Trx, error)
+	GetAllTrxByCursor(userID int, limit int, cursor string) (*model.PaginatedResponse, error)
 	GetTrxByID(id, userID int) (*model.Trx, error)
 	CreateTrx(userID int, req model.CreateTrxRequest) (int, error)
+	MarkTrxPaid(id int) error
 }
 
 type trxUsecase struct {
@@ -66,6 +79,29 @@ func (u *trxUsecase) GetAllTrx(userID int, limit, offset int) ([]model.Trx, erro
 	return u.trxRepo.FindByUserID(userID, limit, offset)
 }
 
+func (u *trxUsecase) GetAllTrxByCursor(userID int, limit int, cursor string) (*model.PaginatedResponse, error) {
+	decoded, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	trxs, err := u.trxRepo.FindByUserIDByCursor(userID, limit, decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	trxs, next, prev := utils.CursorPage(trxs, limit, decoded, func(t model.Trx) (string, int) {
+		return "", t.ID
+	})
+
+	return &model.PaginatedResponse{
+		Limit:      limit,
+		NextCursor: next,
+		PrevCursor: prev,
+		Data:       trxs,
+	}, nil
+}
+
 func (u *trxUsecase) GetTrxByID(id, userID int) (*model.Trx, error) {
 	trx, err := u.trxRepo.FindByIDWithDetails(id)
 	if err != nil {
@@ -81,24 +117,18 @@ func (u *trxUsecase) GetTrxByID(id, userID int) (*model.Trx, error) {
 }
 
 func (u *trxUsecase) CreateTrx(userID int, req model.CreateTrxRequest) (int, error) {
-	// Validate alamat ownership
-	alamat, err := u.alamatRepo.FindByID(req.AlamatPengiriman)
-	if err != nil {
-		return 0, errors.New("alamat not found")
-	}
-	if alamat.IDUser != userID {
-		return 0, errors.New("unauthorized: not your alamat")
-	}
-
-	// Calculate total price and validate products
-	var totalHarga int
-	var details []struct {
+	// Validate products, prices are resolved inside the transaction
+	type trxItem struct {
 		produk    *model.Produk
 		kuantitas int
+		promo     *model.ProdukPromo
+		harga     int
 	}
+	var details []trxItem
+	needsShipping := false
 
 	for _, detail := range req.DetailTrx {
-		produk, err := u.produkRepo.FindByIDWithRelations(detail.ProductID)
+		produk, err := u.produkRepo.FindByIDWithRelations(detail.ProductID, 0)
 		if err != nil {
 			return 0, errors.New("product not found: " + strconv.Itoa(detail.ProductID))
 		}
@@ -108,41 +138,77 @@ func (u *trxUsecase) CreateTrx(userID int, req model.CreateTrxRequest) (int, err
 			return 0, errors.New("product is no longer available: " + produk.NamaProduk)
 		}
 
-		// Check stock
-		if produk.Stok < detail.Kuantitas {
-			return 0, errors.New("insufficient stock for product: " + produk.NamaProduk)
+		// Tokos on vacation take no orders until they reopen
+		if produk.Toko != nil && produk.Toko.OnVacation(time.Now()) {
+			return 0, vacationError(produk.Toko, produk.NamaProduk)
 		}
 
-		// Calculate price
-		hargaKonsumen, _ := strconv.Atoi(produk.HargaKonsumen)
-		detailTotal := hargaKonsumen * detail.Kuantitas
-		totalHarga += detailTotal
-
-		details = append(details, struct {
-			produk    *model.Produk
-			kuantitas int
-		}{
+		// Check stock, bundles use the stock of their components,
+		// pre-orders are only limited by their quota and digital products
+		// have nothing to ship
+		digital := produk.Type == model.ProdukTypeDigital
+		if produk.IsPreorder {
+			if produk.PreorderQuota > 0 && produk.PreorderSold+detail.Kuantitas > produk.PreorderQuota {
+				return 0, fmt.Errorf("only %d left for pre-order of product: %s", produk.PreorderQuota-produk.PreorderSold, produk.NamaProduk)
+			}
+		} else if !digital && availableStock(*produk) < detail.Kuantitas {
+			return 0, errors.New("insufficient stock for product: " + produk.NamaProduk)
+		}
+		needsShipping = needsShipping || !digital
+		details = append(details, trxItem{
 			produk:    produk,
 			kuantitas: detail.Kuantitas,
 		})
 	}
 
+	// Validate alamat ownership, only physical products are shipped
+	var alamatPengiriman *int
+	if req.AlamatPengiriman == 0 && needsShipping {
+		return 0, errors.New("alamat_kirim is required for physical products")
+	}
+	if req.AlamatPengiriman != 0 {
+		alamat, err := u.alamatRepo.FindByID(req.AlamatPengiriman)
+		if err != nil {
+			return 0, errors.New("alamat not found")
+		}
+		if alamat.IDUser != userID {
+			return 0, errors.New("unauthorized: not your alamat")
+		}
+		alamatPengiriman = &alamat.ID
+	}
+
 	// Generate invoice code
 	kodeInvoice := utils.GenerateInvoiceCode()
 
 	now := time.Now()
 	trx := &model.Trx{
 		IDUser:           userID,
-		AlamatPengiriman: req.AlamatPengiriman,
-		HargaTotal:       totalHarga,
+		AlamatPengiriman: alamatPengiriman,
 		KodeInvoice:      kodeInvoice,
 		MethodBayar:      req.MethodBayar,
+		StatusBayar:      model.TrxStatusUnpaid,
 		CreatedAt:        &now,
 		UpdatedAt:        &now,
 	}
 
 	// Use transaction to create trx, detail_trx, and log_produk
-	return trx.ID, u.db.Transaction(func(tx *gorm.DB) error {
+	err := u.db.Transaction(func(tx *gorm.DB) error {
+		// Apply running promos, claiming their quota atomically
+		for i := range details {
+			promo, err := claimPromo(tx, details[i].produk, details[i].kuantitas, now)
+			if err != nil {
+				return err
+			}
+
+			harga, _ := strconv.Atoi(details[i].produk.HargaKonsumen)
+			if promo != nil {
+				harga = promo.PromoPrice
+			}
+			details[i].promo = promo
+			details[i].harga = harga * details[i].kuantitas
+			trx.HargaTotal += details[i].harga
+		}
+
 		// Create trx
 		if err := tx.Create(trx).Error; err != nil {
 			return err
@@ -158,26 +224,30 @@ func (u *trxUsecase) CreateTrx(userID int, req model.CreateTrxRequest) (int, err
 				HargaReseller: detail.produk.HargaReseller,
 				HargaKonsumen: detail.produk.HargaKonsumen,
 				Deskripsi:     detail.produk.Deskripsi,
+				Attributes:    attributeValues(detail.produk.Attributes),
 				IDToko:        detail.produk.IDToko,
 				IDCategory:    detail.produk.IDCategory,
 				CreatedAt:     &now,
 				UpdatedAt:     &now,
 			}
+			logProduk.Bundle = bundleSnapshot(detail.produk)
+			if detail.promo != nil {
+				logProduk.IDPromo = &detail.promo.ID
+				logProduk.PromoLabel = detail.promo.Label
+				logProduk.HargaPromo = &detail.promo.PromoPrice
+			}
 			if err := tx.Create(logProduk).Error; err != nil {
 				return err
 			}
 
-			// Calculate detail price
-			hargaKonsumen, _ := strconv.Atoi(detail.produk.HargaKonsumen)
-			detailHarga := hargaKonsumen * detail.kuantitas
-
 			// Create detail_trx
 			detailTrx := &model.DetailTrx{
 				IDTrx:       trx.ID,
 				IDLogProduk: logProduk.ID,
 				IDToko:      detail.produk.IDToko,
 				Kuantitas:   detail.kuantitas,
-				HargaTotal:  detailHarga,
+				HargaTotal:  detail.harga,
+				ShipBy:      preorderShipBy(detail.produk, now),
 				CreatedAt:   &now,
 				UpdatedAt:   &now,
 			}
@@ -185,13 +255,84 @@ func (u *trxUsecase) CreateTrx(userID int, req model.CreateTrxRequest) (int, err
 				return err
 			}
 
-			// Update product stock
-			detail.produk.Stok -= detail.kuantitas
-			if err := tx.Save(detail.produk).Error; err != nil {
-				return err
+			// Update product stock, a bundle takes stock from each component,
+			// a pre-order takes from its quota instead and a digital product
+			// grants downloads
+			if detail.produk.Type == model.ProdukTypeDigital {
+				if err := grantDownload(tx, trx, detailTrx, detail.produk, now); err != nil {
+					return err
+				}
+			} else if detail.produk.IsPreorder {
+				if err := claimPreorder(tx, detail.produk, detail.kuantitas); err != nil {
+					return err
+				}
+			} else if detail.produk.Type == model.ProdukTypeBundle {
+				for _, item := range detail.produk.BundleItems {
+					nama := detail.produk.NamaProduk
+					if item.Component != nil {
+						nama = item.Component.NamaProduk
+					}
+					if err := decrementStock(tx, item.IDProduk, item.Kuantitas*detail.kuantitas, nama); err != nil {
+						return err
+					}
+					if err := checkLowStock(tx, item.IDProduk, item.Kuantitas*detail.kuantitas); err != nil {
+						return err
+					}
+				}
+			} else {
+				if err := decrementStock(tx, detail.produk.ID, detail.kuantitas, detail.produk.NamaProduk); err != nil {
+					return err
+				}
+				if err := checkLowStock(tx, detail.produk.ID, detail.kuantitas); err != nil {
+					return err
+				}
 			}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	return trx.ID, nil
+}
+
+// MarkTrxPaid records the payment of a transaction and activates the
+// downloads of its digital products
+func (u *trxUsecase) MarkTrxPaid(id int) error {
+	if _, err := u.trxRepo.FindByID(id); err != nil {
+		return errors.New("`No Data Trx`")
+	}
+
+	now := time.Now()
+	return u.db.Transaction(func(tx *gorm.DB) error {
+		// Only an unpaid trx moves to paid, a repeated confirmation is refused
+		result := tx.Model(&model.Trx{}).
+			Where("id = ? AND status_bayar = ?", id, model.TrxStatusUnpaid).
+			Updates(map[string]interface{}{
+				"status_bayar": model.TrxStatusPaid,
+				"paid_at":      now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("transaction is already paid")
+		}
+
+		return activateDownloads(tx, id, now)
+	})
+}
+
+// claimQuota adds n to the counter column of row id unless that would pass
+// its quota column, a quota of 0 is unlimited. The quota is checked by the
+// UPDATE itself so concurrent orders can never oversell it, RowsAffected is 0
+// when the quota ran out. The table is named rather than modelled since
+// counters are create-only fields GORM leaves out of model updates.
+func claimQuota(tx *gorm.DB, table string, id int, counter, quota string, n int) *gorm.DB {
+	return tx.Table(table).
+		Where("id = ? AND ("+quota+" = 0 OR "+counter+" + ? <= "+quota+")", id, n).
+		UpdateColumn(counter, gorm.Expr(counter+" + ?", n))
 }
@@ -3,6 +3,7 @@ package usecase_test
 import (
 	"evermos-api/internal/model"
 	"evermos-api/internal/usecase"
+	"evermos-api/internal/utils"
 	"testing"
 	"time"
 
@@ -80,11 +81,24 @@ func (m *MockTokoRepository) FindByUserID(userID int) (*model.Toko, error) {
 	return args.Get(0).(*model.Toko), args.Error(1)
 }
 
+func (m *MockTokoRepository) FindBySlug(slug string) (*model.Toko, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Toko), args.Error(1)
+}
+
 func (m *MockTokoRepository) FindAll(limit, offset int, nama string) ([]model.Toko, error) {
 	args := m.Called(limit, offset, nama)
 	return args.Get(0).([]model.Toko), args.Error(1)
 }
 
+func (m *MockTokoRepository) FindAllByCursor(limit int, cursor *utils.Cursor, nama string) ([]model.Toko, error) {
+	args := m.Called(limit, cursor, nama)
+	return args.Get(0).([]model.Toko), args.Error(1)
+}
+
 func (m *MockTokoRepository) Update(toko *model.Toko) error {
 	args := m.Called(toko)
 	return args.Error(0)
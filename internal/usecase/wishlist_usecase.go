@@ -0,0 +1,186 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : wishlist_usecase.go
+// Description  : Business logic untuk wishlist (produk favorit)
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi logic tambah, hapus, dan daftar wishlist user
+// - Produk ditandai is_wishlisted untuk user yang login
+// - Penjual melihat jumlah user yang menyimpan produknya (wishlist_count)
+// - User diberi notifikasi saat harga konsumen produk di wishlist turun
+//
+// ============================================================================
+
+package usecase
+
+import (
+	"errors"
+	"evermos-api/internal/model"
+	"evermos-api/internal/repository"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WishlistUsecase interface
+type WishlistUsecase interface {
+	AddToWishlist(produkID, userID int) error
+	RemoveFromWishlist(produkID, userID int) error
+	GetMyWishlist(userID int, limit, offset int) (*model.PaginatedResponse, error)
+}
+
+type wishlistUsecase struct {
+	wishlistRepo repository.WishlistRepository
+	produkRepo   repository.ProdukRepository
+	promoRepo    repository.ProdukPromoRepository
+}
+
+// NewWishlistUsecase creates new wishlist usecase
+func NewWishlistUsecase(
+	wishlistRepo repository.WishlistRepository,
+	produkRepo repository.ProdukRepository,
+	promoRepo repository.ProdukPromoRepository,
+) WishlistUsecase {
+	return &wishlistUsecase{
+		wishlistRepo: wishlistRepo,
+		produkRepo:   produkRepo,
+		promoRepo:    promoRepo,
+	}
+}
+
+// AddToWishlist saves a visible product to the user's wishlist, adding it
+// again is a no-op
+func (u *wishlistUsecase) AddToWishlist(produkID, userID int) error {
+	if _, err := u.produkRepo.FindByIDWithRelations(produkID, 0); err != nil {
+		return errors.New("product not found")
+	}
+
+	if _, err := u.wishlistRepo.FindByUserAndProduk(userID, produkID); err == nil {
+		return nil
+	}
+
+	now := time.Now()
+	return u.wishlistRepo.Create(&model.Wishlist{
+		IDUser:    userID,
+		IDProduk:  produkID,
+		CreatedAt: &now,
+	})
+}
+
+func (u *wishlistUsecase) RemoveFromWishlist(produkID, userID int) error {
+	wishlist, err := u.wishlistRepo.FindByUserAndProduk(userID, produkID)
+	if err != nil {
+		return errors.New("product is not in your wishlist")
+	}
+	return u.wishlistRepo.Delete(wishlist.ID)
+}
+
+func (u *wishlistUsecase) GetMyWishlist(userID int, limit, offset int) (*model.PaginatedResponse, error) {
+	wishlists, err := u.wishlistRepo.FindByUserID(userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	produks := make([]model.Produk, 0, len(wishlists))
+	for _, wishlist := range wishlists {
+		if wishlist.Produk != nil {
+			produks = append(produks, *wishlist.Produk)
+		}
+	}
+	fillPromos(u.promoRepo, produks, time.Now())
+	fillBundleStock(produks)
+
+	wishlisted := true
+	j := 0
+	for i := range wishlists {
+		if wishlists[i].Produk == nil {
+			continue
+		}
+		produks[j].IsWishlisted = &wishlisted
+		wishlists[i].Produk = &produks[j]
+		j++
+	}
+
+	return &model.PaginatedResponse{
+		Page:  (offset / limit) + 1,
+		Limit: limit,
+		Data:  wishlists,
+	}, nil
+}
+
+// fillWishlisted sets is_wishlisted on products for an authenticated user
+func fillWishlisted(wishlistRepo repository.WishlistRepository, userID int, produks []model.Produk) {
+	if userID <= 0 || len(produks) == 0 {
+		return
+	}
+
+	ids := make([]int, 0, len(produks))
+	for _, produk := range produks {
+		ids = append(ids, produk.ID)
+	}
+
+	wishlistedIDs, err := wishlistRepo.FindWishlistedIDs(userID, ids)
+	if err != nil {
+		return
+	}
+	wishlisted := make(map[int]bool, len(wishlistedIDs))
+	for _, id := range wishlistedIDs {
+		wishlisted[id] = true
+	}
+
+	for i := range produks {
+		value := wishlisted[produks[i].ID]
+		produks[i].IsWishlisted = &value
+	}
+}
+
+// fillWishlistCounts sets how many users wishlisted each product, only
+// exposed to the owning seller
+func fillWishlistCounts(wishlistRepo repository.WishlistRepository, produks []model.Produk) {
+	if len(produks) == 0 {
+		return
+	}
+
+	ids := make([]int, 0, len(produks))
+	for _, produk := range produks {
+		ids = append(ids, produk.ID)
+	}
+
+	counts, err := wishlistRepo.CountByProdukIDs(ids)
+	if err != nil {
+		return
+	}
+	for i := range produks {
+		count := counts[produks[i].ID]
+		produks[i].WishlistCount = &count
+	}
+}
+
+// notifyPriceDrop notifies users who wishlisted a product inside tx when its
+// consumer price went below oldKonsumen
+func notifyPriceDrop(tx *gorm.DB, produk *model.Produk, oldKonsumen string) error {
+	oldPrice, err := strconv.Atoi(oldKonsumen)
+	if err != nil {
+		return nil
+	}
+	newPrice, err := strconv.Atoi(produk.HargaKonsumen)
+	if err != nil || newPrice >= oldPrice {
+		return nil
+	}
+
+	var userIDs []int
+	if err := tx.Model(&model.Wishlist{}).Where("id_produk = ?", produk.ID).Pluck("id_user", &userIDs).Error; err != nil {
+		return err
+	}
+
+	return notifyAll(tx, userIDs, model.NotificationPriceDrop,
+		"Harga turun",
+		fmt.Sprintf("Harga produk \"%s\" turun dari Rp%d menjadi Rp%d.", produk.NamaProduk, oldPrice, newPrice),
+		produk.ID)
+}
@@ -0,0 +1,267 @@
+// ============================================================================
+// Project Name : GoShop API
+// File         : image.go
+// Description  : Utility untuk memproses gambar hasil upload
+// Author       : Zaki Fuadi
+// Version      : v1.0
+// License      : MIT
+// ============================================================================
+//
+// Notes:
+// - File ini berisi pipeline gambar: sniffing konten, resize, dan re-encode
+// - Metadata EXIF dibuang karena gambar di-encode ulang ke JPEG
+// - Orientasi EXIF diterapkan terlebih dahulu agar foto tidak terbalik
+// - Menghasilkan thumbnail dengan beberapa ukuran (mis. 150/400/800 px)
+//
+// ============================================================================
+
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	xdraw "golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ImageOptions holds image processing configuration
+type ImageOptions struct {
+	MaxDimension   int
+	MaxPixels      int
+	ThumbnailSizes []int
+	JPEGQuality    int
+}
+
+// ImageProcessing is the active image processing configuration
+var ImageProcessing = ImageOptions{
+	MaxDimension:   1600,
+	MaxPixels:      40_000_000,
+	ThumbnailSizes: []int{150, 400, 800},
+	JPEGQuality:    85,
+}
+
+var allowedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// ProcessedImage holds re-encoded image and its thumbnails keyed by size
+type ProcessedImage struct {
+	Original   []byte
+	Thumbnails map[int][]byte
+}
+
+// ProcessImage validates image content, applies EXIF orientation, resizes
+// to the max dimension and re-encodes to JPEG with generated thumbnails
+func ProcessImage(data []byte, thumbnailSizes []int) (*ProcessedImage, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedContentTypes[contentType] {
+		return nil, fmt.Errorf("file content is not an allowed image: %s", contentType)
+	}
+
+	opts := ImageProcessing
+
+	// Check declared dimensions before decoding so a small file that claims
+	// a huge canvas cannot allocate gigabytes (decompression bomb)
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, fmt.Errorf("image has invalid dimensions: %dx%d", config.Width, config.Height)
+	}
+	if opts.MaxPixels > 0 && config.Width > opts.MaxPixels/config.Height {
+		return nil, fmt.Errorf("image dimensions too large: %dx%d", config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if contentType == "image/jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	original, err := encodeJPEG(fitImage(img, opts.MaxDimension), opts.JPEGQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProcessedImage{
+		Original:   original,
+		Thumbnails: make(map[int][]byte, len(thumbnailSizes)),
+	}
+	for _, size := range thumbnailSizes {
+		thumb, err := encodeJPEG(fitImage(img, size), opts.JPEGQuality)
+		if err != nil {
+			return nil, err
+		}
+		result.Thumbnails[size] = thumb
+	}
+
+	return result, nil
+}
+
+// ThumbnailFilename returns the variant filename for a processed image
+func ThumbnailFilename(filename string, size int) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "_" + strconv.Itoa(size) + ext
+}
+
+// fitImage scales image down to fit within max x max, never upscales
+func fitImage(img image.Image, max int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if max <= 0 || (width <= max && height <= max) {
+		return img
+	}
+
+	if width >= height {
+		height = height * max / width
+		width = max
+	} else {
+		width = width * max / height
+		height = max
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, xdraw.Over, nil)
+	return dst
+}
+
+// encodeJPEG flattens transparency onto white and encodes as JPEG
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	bounds := img.Bounds()
+	canvas := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(canvas, canvas.Bounds(), img, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jpegOrientation reads the EXIF orientation tag (1-8) of a JPEG, 1 if absent
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 1
+		}
+		marker := data[pos+1]
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if marker == 0xDA || length < 2 || pos+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[pos+4 : pos+2+length]
+		if marker == 0xE1 && len(segment) > 14 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+		pos += 2 + length
+	}
+	return 1
+}
+
+// tiffOrientation finds tag 0x0112 in IFD0 of a TIFF block
+func tiffOrientation(tiff []byte) int {
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// applyOrientation rotates/flips image according to EXIF orientation
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Orientations 5-8 swap width and height
+	dstW, dstH := width, height
+	if orientation >= 5 {
+		dstW, dstH = height, width
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// exifTIFF builds a minimal TIFF block with an orientation tag in IFD0
+func exifTIFF(order binary.ByteOrder, orientation uint16) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:4], 42)
+	order.PutUint32(tiff[4:8], 8)
+	order.PutUint16(tiff[8:10], 1)
+	order.PutUint16(tiff[10:12], 0x0112)
+	order.PutUint16(tiff[12:14], 3)
+	order.PutUint32(tiff[14:18], 1)
+	order.PutUint16(tiff[18:20], orientation)
+	return tiff
+}
+
+// withExif inserts an APP1 Exif segment right after the JPEG SOI marker
+func withExif(jpg, tiff []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+func encodeTestJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+// encodeTestPNG encodes a 1x1 PNG and rewrites IHDR to declare the given size
+func encodeTestPNG(t *testing.T, width, height uint32) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1, 1))))
+	data := buf.Bytes()
+
+	// Signature (8) + length (4), then "IHDR" + 13 bytes of data + CRC
+	ihdr := data[12 : 12+4+13]
+	binary.BigEndian.PutUint32(ihdr[4:8], width)
+	binary.BigEndian.PutUint32(ihdr[8:12], height)
+	binary.BigEndian.PutUint32(data[12+4+13:], crc32.ChecksumIEEE(ihdr))
+	return data
+}
+
+func TestJpegOrientation(t *testing.T) {
+	jpg := encodeTestJPEG(t, 2, 2)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected int
+	}{
+		{"No Exif", jpg, 1},
+		{"Little Endian", withExif(jpg, exifTIFF(binary.LittleEndian, 6)), 6},
+		{"Big Endian", withExif(jpg, exifTIFF(binary.BigEndian, 8)), 8},
+		{"Out Of Range", withExif(jpg, exifTIFF(binary.LittleEndian, 9)), 1},
+		{"Unknown Byte Order", withExif(jpg, append([]byte("XX"), exifTIFF(binary.LittleEndian, 6)[2:]...)), 1},
+		{"Not JPEG", []byte("GIF89a"), 1},
+		{"Truncated", jpg[:3], 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jpegOrientation(tt.data))
+		})
+	}
+}
+
+func TestTiffOrientation_BadOffset(t *testing.T) {
+	tiff := exifTIFF(binary.LittleEndian, 6)
+	binary.LittleEndian.PutUint32(tiff[4:8], 1000)
+	assert.Equal(t, 1, tiffOrientation(tiff))
+
+	tiff = exifTIFF(binary.LittleEndian, 6)
+	binary.LittleEndian.PutUint16(tiff[8:10], 5)
+	assert.Equal(t, 6, tiffOrientation(tiff))
+	binary.LittleEndian.PutUint16(tiff[10:12], 0x0100)
+	assert.Equal(t, 1, tiffOrientation(tiff))
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 3x2 image with a marker pixel at the top-left corner
+	marker := color.RGBA{R: 255, A: 255}
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, marker)
+
+	tests := []struct {
+		orientation int
+		width       int
+		height      int
+		x, y        int
+	}{
+		{1, 3, 2, 0, 0},
+		{2, 3, 2, 2, 0},
+		{3, 3, 2, 2, 1},
+		{4, 3, 2, 0, 1},
+		{5, 2, 3, 0, 0},
+		{6, 2, 3, 1, 0},
+		{7, 2, 3, 1, 2},
+		{8, 2, 3, 0, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.orientation), func(t *testing.T) {
+			dst := applyOrientation(src, tt.orientation)
+			assert.Equal(t, tt.width, dst.Bounds().Dx())
+			assert.Equal(t, tt.height, dst.Bounds().Dy())
+
+			r, _, _, _ := dst.At(tt.x, tt.y).RGBA()
+			assert.Equal(t, uint32(0xFFFF), r)
+		})
+	}
+}
+
+func TestProcessImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr string
+	}{
+		{"Plain Text", []byte("hello, this is not an image"), "not an allowed image"},
+		{"HTML", []byte("<html><body>x</body></html>"), "not an allowed image"},
+		{"PDF", []byte("%PDF-1.4\n"), "not an allowed image"},
+		{"Decompression Bomb", encodeTestPNG(t, 60000, 60000), "too large"},
+		{"Valid PNG", encodeTestPNG(t, 1, 1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ProcessImage(tt.data, []int{150})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, result.Original)
+			assert.Contains(t, result.Thumbnails, 150)
+		})
+	}
+}
+
+func TestProcessImage_AppliesOrientation(t *testing.T) {
+	data := withExif(encodeTestJPEG(t, 40, 20), exifTIFF(binary.BigEndian, 6))
+
+	result, err := ProcessImage(data, nil)
+	require.NoError(t, err)
+
+	img, err := jpeg.Decode(bytes.NewReader(result.Original))
+	require.NoError(t, err)
+	assert.Equal(t, 20, img.Bounds().Dx())
+	assert.Equal(t, 40, img.Bounds().Dy())
+}
@@ -11,12 +11,16 @@
 // - File ini berisi fungsi untuk extract pagination params dari query
 // - Mendukung parameter page dan limit
 // - Menghitung offset untuk query database
+// - Mendukung cursor (keyset) pagination melalui parameter cursor
 //
 // ============================================================================
 
 package utils
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -24,9 +28,18 @@ import (
 
 // PaginationParams holds pagination parameters
 type PaginationParams struct {
-	Page   int
-	Limit  int
-	Offset int
+	Page      int
+	Limit     int
+	Offset    int
+	Cursor    string
+	UseCursor bool
+}
+
+// Cursor is the decoded form of an opaque keyset pagination cursor
+type Cursor struct {
+	Key      string `json:"k,omitempty"`
+	ID       int    `json:"id"`
+	Backward bool   `json:"b,omitempty"`
 }
 
 // GetPaginationParams extracts pagination params from query
@@ -46,9 +59,72 @@ func GetPaginationParams(c *gin.Context) PaginationParams {
 
 	offset := (page - 1) * limit
 
+	// Cursor mode is enabled by the presence of the cursor param,
+	// an empty value requests the first page
+	cursor, useCursor := c.GetQuery("cursor")
+
 	return PaginationParams{
-		Page:   page,
-		Limit:  limit,
-		Offset: offset,
+		Page:      page,
+		Limit:     limit,
+		Offset:    offset,
+		Cursor:    cursor,
+		UseCursor: useCursor,
+	}
+}
+
+// EncodeCursor encodes cursor into an opaque URL-safe string
+func EncodeCursor(cursor Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes an opaque cursor string, empty string returns nil
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID < 1 {
+		return nil, errors.New("invalid cursor")
 	}
+
+	return &cursor, nil
+}
+
+// CursorPage trims the lookahead row fetched by the repository (limit+1),
+// restores ascending order for backward pages and builds next/prev cursors
+func CursorPage[T any](items []T, limit int, cursor *Cursor, keyOf func(T) (string, int)) ([]T, string, string) {
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if len(items) == 0 {
+		return items, "", ""
+	}
+
+	var next, prev string
+	if hasMore || backward {
+		key, id := keyOf(items[len(items)-1])
+		next = EncodeCursor(Cursor{Key: key, ID: id})
+	}
+	if cursor != nil && (!backward || hasMore) {
+		key, id := keyOf(items[0])
+		prev = EncodeCursor(Cursor{Key: key, ID: id, Backward: true})
+	}
+
+	return items, next, prev
 }
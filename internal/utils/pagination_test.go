@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorPage(t *testing.T) {
+	keyOf := func(id int) (string, int) { return "", id }
+
+	tests := []struct {
+		name     string
+		items    []int
+		cursor   *Cursor
+		expected []int
+		next     *Cursor
+		prev     *Cursor
+	}{
+		{
+			name:     "First Page",
+			items:    []int{1, 2, 3},
+			expected: []int{1, 2},
+			next:     &Cursor{ID: 2},
+		},
+		{
+			name:     "First Page Without More",
+			items:    []int{1, 2},
+			expected: []int{1, 2},
+		},
+		{
+			name:     "Middle Page",
+			items:    []int{3, 4, 5},
+			cursor:   &Cursor{ID: 2},
+			expected: []int{3, 4},
+			next:     &Cursor{ID: 4},
+			prev:     &Cursor{ID: 3, Backward: true},
+		},
+		{
+			name:     "Last Page",
+			items:    []int{5},
+			cursor:   &Cursor{ID: 4},
+			expected: []int{5},
+			prev:     &Cursor{ID: 5, Backward: true},
+		},
+		{
+			name:     "Backward Page",
+			items:    []int{4, 3, 2},
+			cursor:   &Cursor{ID: 5, Backward: true},
+			expected: []int{3, 4},
+			next:     &Cursor{ID: 4},
+			prev:     &Cursor{ID: 3, Backward: true},
+		},
+		{
+			name:     "Backward To First Page",
+			items:    []int{2, 1},
+			cursor:   &Cursor{ID: 3, Backward: true},
+			expected: []int{1, 2},
+			next:     &Cursor{ID: 2},
+		},
+		{
+			name:     "Empty Page",
+			items:    []int{},
+			cursor:   &Cursor{ID: 9},
+			expected: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, next, prev := CursorPage(tt.items, 2, tt.cursor, keyOf)
+			assert.Equal(t, tt.expected, items)
+			assertCursor(t, tt.next, next)
+			assertCursor(t, tt.prev, prev)
+		})
+	}
+}
+
+func TestCursorPage_Key(t *testing.T) {
+	scores := map[int]int{7: 50, 3: 40, 9: 40}
+	keyOf := func(id int) (string, int) { return strconv.Itoa(scores[id]), id }
+
+	_, next, prev := CursorPage([]int{7, 3, 9}, 2, &Cursor{Key: "60", ID: 1}, keyOf)
+	assertCursor(t, &Cursor{Key: "40", ID: 3}, next)
+	assertCursor(t, &Cursor{Key: "50", ID: 7, Backward: true}, prev)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := Cursor{Key: "40", ID: 3, Backward: true}
+	decoded, err := DecodeCursor(EncodeCursor(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	decoded, err = DecodeCursor("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = DecodeCursor("not-a-cursor!")
+	assert.Error(t, err)
+
+	_, err = DecodeCursor(EncodeCursor(Cursor{ID: 0}))
+	assert.Error(t, err)
+}
+
+// assertCursor checks an encoded cursor, nil expects no cursor
+func assertCursor(t *testing.T, expected *Cursor, encoded string) {
+	t.Helper()
+	if expected == nil {
+		assert.Empty(t, encoded)
+		return
+	}
+
+	decoded, err := DecodeCursor(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, *decoded)
+}
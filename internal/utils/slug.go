@@ -9,36 +9,145 @@
 //
 // Notes:
 // - File ini berisi fungsi untuk generate slug dari string
-// - Mengonversi ke lowercase dan replace spasi dengan hyphen
-// - Menghapus karakter special dan multiple hyphen
+// - Normalisasi Unicode (hapus aksen) dan transliterasi huruf non-Latin
+// - Karakter selain huruf/angka menjadi hyphen, multiple hyphen digabung
+// - Panjang dibatasi SlugMaxLength dikurangi ruang untuk suffix -N dan
+//   dipotong di batas kata
+// - Hasil tidak pernah kosong, reserved words dianggap sudah dipakai saat
+//   mencari slug unik sehingga diberi suffix seperti slug yang bentrok
 //
 // ============================================================================
 
 package utils
 
 import (
-	"regexp"
+	"fmt"
+	"hash/fnv"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
+// SlugMaxLength is the maximum length of a stored slug
+const SlugMaxLength = 80
+
+// slugSuffixRoom is left free below SlugMaxLength for the "-N" suffix a
+// colliding slug gets, up to "-99999"
+const slugSuffixRoom = 6
+
+// ReservedSlugs cannot be used as a slug as-is since they clash with routes,
+// unique slugs treat them as taken
+var ReservedSlugs = map[string]bool{
+	"admin":  true,
+	"api":    true,
+	"all":    true,
+	"edit":   true,
+	"media":  true,
+	"my":     true,
+	"new":    true,
+	"search": true,
+	"slug":   true,
+}
+
+// transliterations covers letters that do not decompose into ASCII
+var transliterations = map[rune]string{
+	// Latin
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'ł': "l",
+	'þ': "th", 'ı': "i", 'ħ': "h", 'ŋ': "ng",
+	// Cyrillic
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	// Greek
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i",
+	'θ': "th", 'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x",
+	'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y",
+	'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// stripMarks removes combining accents after canonical decomposition
+var stripMarks = transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
 // GenerateSlug generates URL-friendly slug from string
 func GenerateSlug(s string) string {
-	// Convert to lowercase
-	slug := strings.ToLower(s)
+	// Convert to lowercase and remove accents (é -> e, ﬁ -> fi)
+	normalized, _, err := transform.String(stripMarks, strings.ToLower(s))
+	if err != nil {
+		normalized = strings.ToLower(s)
+	}
 
-	// Replace spaces with hyphens
-	slug = strings.ReplaceAll(slug, " ", "-")
+	// Transliterate and replace everything else with hyphens
+	var b strings.Builder
+	for _, r := range normalized {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+			// Apostrophes join words: "men's" -> "mens"
+		default:
+			if t, ok := transliterations[r]; ok {
+				b.WriteString(t)
+			} else {
+				b.WriteRune('-')
+			}
+		}
+	}
 
-	// Remove special characters
-	reg := regexp.MustCompile("[^a-z0-9-]+")
-	slug = reg.ReplaceAllString(slug, "")
+	// Remove multiple consecutive hyphens and trim them from start and end
+	slug := strings.Trim(collapseHyphens(b.String()), "-")
+	slug = truncateSlug(slug, SlugMaxLength-slugSuffixRoom)
 
-	// Remove multiple consecutive hyphens
-	reg = regexp.MustCompile("-+")
-	slug = reg.ReplaceAllString(slug, "-")
-
-	// Trim hyphens from start and end
-	slug = strings.Trim(slug, "-")
+	// Names without any transliterable character (emoji, CJK, ...)
+	if slug == "" {
+		return fallbackSlug(s)
+	}
 
 	return slug
 }
+
+// collapseHyphens replaces runs of hyphens with a single one
+func collapseHyphens(s string) string {
+	var b strings.Builder
+	prev := false
+	for _, r := range s {
+		if r == '-' {
+			if prev {
+				continue
+			}
+			prev = true
+		} else {
+			prev = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// truncateSlug shortens slug to max bytes, cutting at the last word boundary
+func truncateSlug(slug string, max int) string {
+	if len(slug) <= max {
+		return slug
+	}
+
+	cut := slug[:max]
+	if slug[max] != '-' {
+		// Keep whole words unless the first word alone exceeds max
+		if i := strings.LastIndex(cut, "-"); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.Trim(cut, "-")
+}
+
+// fallbackSlug builds a stable slug for input that yields no usable characters
+func fallbackSlug(s string) string {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return fmt.Sprintf("item-%08x", h.Sum32())
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ASCII", "Kaos Polos Hitam", "kaos-polos-hitam"},
+		{"Special Characters", "Men's T-Shirt (XL) & Cap!", "mens-t-shirt-xl-cap"},
+		{"Accents", "Café Crème Brûlée", "cafe-creme-brulee"},
+		{"Latin Ligatures", "Straße Æther", "strasse-aether"},
+		{"Cyrillic", "Москва", "moskva"},
+		{"Greek", "Αθήνα", "athina"},
+		{"Reserved Word", "My", "my"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GenerateSlug(tt.input))
+		})
+	}
+}
+
+func TestGenerateSlug_Fallback(t *testing.T) {
+	slug := GenerateSlug("🍜🍣")
+	assert.True(t, strings.HasPrefix(slug, "item-"))
+	assert.Equal(t, slug, GenerateSlug("🍜🍣"))
+	assert.NotEqual(t, slug, GenerateSlug("東京"))
+	assert.NotEmpty(t, GenerateSlug(""))
+}
+
+func TestGenerateSlug_MaxLength(t *testing.T) {
+	slug := GenerateSlug(strings.Repeat("panjang ", 20))
+	assert.LessOrEqual(t, len(slug+"-99999"), SlugMaxLength)
+	assert.False(t, strings.HasSuffix(slug, "-"))
+	assert.True(t, strings.HasSuffix(slug, "panjang"))
+
+	slug = GenerateSlug(strings.Repeat("a", 100))
+	assert.Len(t, slug, SlugMaxLength-slugSuffixRoom)
+}
@@ -9,8 +9,12 @@
 //
 // Notes:
 // - File ini berisi fungsi untuk handle upload file
-// - Validasi ekstensi file (jpg, jpeg, png, gif)
+// - Validasi ekstensi file (jpg, jpeg, png, gif, webp) dan isi file
+// - Gambar diproses ulang (resize, re-encode JPEG) beserta thumbnail
 // - Generate unique filename menggunakan MD5 hash
+// - File disimpan melalui storage backend (local atau S3-compatible)
+// - File produk digital (pdf, epub, zip, dll) disimpan apa adanya di prefix
+//   privat sehingga hanya bisa diakses dengan signed URL
 //
 // ============================================================================
 
@@ -18,12 +22,14 @@ package utils
 
 import (
 	"crypto/md5"
+	"evermos-api/internal/storage"
 	"fmt"
 	"io"
 	"math/rand"
 	"mime/multipart"
-	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -33,58 +39,120 @@ var allowedExtensions = map[string]bool{
 	".jpeg": true,
 	".png":  true,
 	".gif":  true,
+	".webp": true,
+}
+
+var allowedDigitalExtensions = map[string]bool{
+	".pdf":  true,
+	".epub": true,
+	".mobi": true,
+	".zip":  true,
+	".txt":  true,
+	".mp3":  true,
+	".mp4":  true,
+	".docx": true,
+	".xlsx": true,
+	".pptx": true,
+}
+
+// UploadedImage holds stored image path and its thumbnail paths keyed by size
+type UploadedImage struct {
+	URL   string
+	Sizes map[string]string
 }
 
 // UploadFile handles file upload
-func UploadFile(file *multipart.FileHeader, uploadPath string, subDir string) (string, error) {
-	// Create directory if not exists
-	dir := filepath.Join(uploadPath, subDir)
-	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
-		return "", fmt.Errorf("failed to create directory: %w", err)
+func UploadFile(file *multipart.FileHeader, store storage.Storage, subDir string) (string, error) {
+	uploaded, err := UploadImage(file, store, subDir, nil)
+	if err != nil {
+		return "", err
 	}
+	return uploaded.URL, nil
+}
 
+// UploadImage validates, processes and stores an image with its thumbnails
+func UploadImage(file *multipart.FileHeader, store storage.Storage, subDir string, thumbnailSizes []int) (*UploadedImage, error) {
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(file.Filename))
 	if !allowedExtensions[ext] {
-		return "", fmt.Errorf("file type not allowed: %s", ext)
+		return nil, fmt.Errorf("file type not allowed: %s", ext)
 	}
 
-	// Generate unique filename
-	// timestamp := time.Now().Unix()
-	// filename := fmt.Sprintf("%d-%s", timestamp, file.Filename)
-	// filePath := filepath.Join(dir, filename)
-
-	// Generate unique filename with hash
-	uniqueFilename := generateUniqueFilename(file.Filename)
-	filePath := filepath.Join(dir, uniqueFilename)
-
 	// Open uploaded file
 	src, err := file.Open()
 	if err != nil {
-		return "", fmt.Errorf("failed to open file: %w", err)
+		return nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer src.Close()
 
-	// Create destination file
-	dst, err := os.Create(filePath)
+	data, err := io.ReadAll(src)
 	if err != nil {
-		return "", fmt.Errorf("failed to create file: %w", err)
+		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
-	defer dst.Close()
 
-	// Copy file
-	if _, err := io.Copy(dst, src); err != nil {
-		return "", fmt.Errorf("failed to save file: %w", err)
+	// Content is sniffed and re-encoded, the extension alone is not trusted
+	processed, err := ProcessImage(data, thumbnailSizes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate unique filename with hash, processed images are always JPEG
+	uniqueFilename := generateUniqueFilename(".jpg")
+	uploaded := &UploadedImage{
+		URL:   path.Join(subDir, uniqueFilename),
+		Sizes: make(map[string]string, len(processed.Thumbnails)),
+	}
+
+	if err := store.Put(uploaded.URL, processed.Original, "image/jpeg"); err != nil {
+		return nil, err
+	}
+
+	for size, thumb := range processed.Thumbnails {
+		thumbKey := path.Join(subDir, ThumbnailFilename(uniqueFilename, size))
+		if err := store.Put(thumbKey, thumb, "image/jpeg"); err != nil {
+			_ = DeleteImage(store, uploaded.URL, uploaded.Sizes)
+			return nil, err
+		}
+		uploaded.Sizes[strconv.Itoa(size)] = thumbKey
 	}
 
 	// Return relative path
-	// return filepath.Join(subDir, filename), nil
-	return filepath.Join(subDir, uniqueFilename), nil
+	return uploaded, nil
+}
+
+// UploadDigitalFile stores a digital product file under the private media
+// prefix and returns its key
+func UploadDigitalFile(file *multipart.FileHeader, store storage.Storage, subDir string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !allowedDigitalExtensions[ext] {
+		return "", fmt.Errorf("file type not allowed: %s", ext)
+	}
+
+	prefix := PrivateMediaPrefix()
+	if prefix == "" || !MediaSigningEnabled() {
+		return "", fmt.Errorf("private media storage is not configured")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	key := path.Join(prefix, subDir, generateUniqueFilename(ext))
+	if err := store.Put(key, data, storage.ContentType(key)); err != nil {
+		return "", err
+	}
+	return key, nil
 }
 
 // generateUniqueFilename generates unique filename using timestamp and random hash
-func generateUniqueFilename(originalFilename string) string {
-	ext := filepath.Ext(originalFilename)
+func generateUniqueFilename(ext string) string {
 	timestamp := time.Now().UnixNano()
 	randomNum := rand.Int63()
 
@@ -95,16 +163,22 @@ func generateUniqueFilename(originalFilename string) string {
 	return fmt.Sprintf("%s%s", hashString, ext)
 }
 
-// DeleteFile deletes a file
-func DeleteFile(uploadPath string, filename string) error {
+// DeleteFile deletes a file, missing files are ignored
+func DeleteFile(store storage.Storage, filename string) error {
 	if filename == "" {
 		return nil
 	}
 
-	filePath := filepath.Join(uploadPath, filename)
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return nil
-	}
+	return store.Delete(filename)
+}
 
-	return os.Remove(filePath)
+// DeleteImage deletes an image together with its thumbnails
+func DeleteImage(store storage.Storage, filename string, sizes map[string]string) error {
+	err := DeleteFile(store, filename)
+	for _, thumb := range sizes {
+		if thumbErr := DeleteFile(store, thumb); thumbErr != nil && err == nil {
+			err = thumbErr
+		}
+	}
+	return err
 }